@@ -60,9 +60,8 @@ type demo struct {
 }
 
 type bubbleData struct {
-	speed    float64
-	alpha    float64
-	endScale float64
+	speed float64
+	alpha float64
 }
 
 var demos = []demo{
@@ -70,6 +69,9 @@ var demos = []demo{
 	{"Fountain", fountain, 0.5, 0.9},
 	{"Vortex", vortex, 0.5, 0.5},
 	{"BOIDS", boids, 0.5, 0.5},
+	{"Smoke", smoke, 0.5, 0.9},
+	{"Stir (move the mouse)", stir, 0.5, 0.5},
+	{"Fireworks", fireworks, 0.5, 0.9},
 }
 
 var gravity = twodeeparticles.Vector{0.0, 150}
@@ -118,9 +120,11 @@ func (g *game) Draw(screen *ebiten.Image) {
 	g.particles.Update(now)
 
 	w, h := screen.Size()
-	originX, originY := int(float64(w)*demos[g.demoIndex].xOriginOffset), int(float64(h)*demos[g.demoIndex].yOriginOffset)
+	origin := twodeeparticles.Vector{float64(w) * demos[g.demoIndex].xOriginOffset, float64(h) * demos[g.demoIndex].yOriginOffset}
+	g.particles.SetViewTransform(origin, 1.0, 0.0)
+
 	g.particles.ForEachParticle(func(p *twodeeparticles.Particle, t twodeeparticles.NormalizedDuration, delta time.Duration) {
-		g.drawParticle(screen, p, t, originX, originY)
+		g.drawParticle(screen, p, t)
 	}, now)
 
 	ebitenutil.DebugPrintAt(screen,
@@ -130,23 +134,29 @@ func (g *game) Draw(screen *ebiten.Image) {
 	ebitenutil.DebugPrintAt(screen, "github.com/blizzy78/twodeeparticles", 10, h-25)
 }
 
-func (g *game) drawParticle(screen *ebiten.Image, p *twodeeparticles.Particle, t twodeeparticles.NormalizedDuration, originX int, originY int) {
+func (g *game) drawParticle(screen *ebiten.Image, p *twodeeparticles.Particle, t twodeeparticles.NormalizedDuration) {
+	if !p.Visible() {
+		return
+	}
+
 	g.drawOpts.GeoM.Reset()
 	g.drawOpts.ColorM.Reset()
 
 	w, h := g.dot.Size()
-	g.drawOpts.GeoM.Translate(float64(-w/2), float64(-h/2))
+	pivot := p.Pivot()
+	g.drawOpts.GeoM.Translate(float64(-w/2)-pivot.X, float64(-h/2)-pivot.Y)
 
 	s := p.Scale()
 	g.drawOpts.GeoM.Scale(s.X, s.Y)
 
+	sk := p.Skew()
+	g.drawOpts.GeoM.Skew(sk.X, sk.Y)
+
 	g.drawOpts.GeoM.Rotate(p.Angle())
 
-	pos := p.Position()
+	pos := g.particles.LocalToScreen(p.Position())
 	g.drawOpts.GeoM.Translate(pos.X, pos.Y)
 
-	g.drawOpts.GeoM.Translate(float64(originX), float64(originY))
-
 	_, _, _, a := p.Color().RGBA()
 	g.drawOpts.ColorM.Scale(1.0, 1.0, 1.0, float64(a)/65535.0)
 
@@ -172,7 +182,6 @@ func bubbles(rand *rand.Rand) *twodeeparticles.ParticleSystem {
 
 		data := particleDataPool.Get().(*bubbleData)
 		data.speed = randomValue(startSpeed-startSpeedVariance/2.0, startSpeed+startSpeedVariance/2.0, rand)
-		data.endScale = randomValue(endScale-endScaleVariance/2.0, endScale+endScaleVariance/2.0, rand)
 		data.alpha = randomValue(minAlpha, 1.0, rand)
 		return data
 	}
@@ -198,16 +207,15 @@ func bubbles(rand *rand.Rand) *twodeeparticles.ParticleSystem {
 		return time.Duration((mt+fadeOutTime)*1000.0) * time.Millisecond
 	}
 
+	s.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos twodeeparticles.Vector) twodeeparticles.Vector {
+		a := randomValue(0.0, 360.0, rand)
+		return angleToDirection(a)
+	}
+
 	s.VelocityOverLifetime = func(p *twodeeparticles.Particle, t twodeeparticles.NormalizedDuration, delta time.Duration) twodeeparticles.Vector {
 		data := p.Data().(*bubbleData)
 
 		s := t.Duration(p.Lifetime()).Seconds()
-		if s == 0 {
-			a := randomValue(0.0, 360.0, rand)
-			dir := angleToDirection(a)
-			return dir.Multiply(data.speed)
-		}
-
 		moveTime := p.Lifetime().Seconds() - fadeOutTime
 		if s > moveTime {
 			return twodeeparticles.ZeroVector
@@ -218,22 +226,25 @@ func bubbles(rand *rand.Rand) *twodeeparticles.ParticleSystem {
 		return dir.Multiply(data.speed * m)
 	}
 
-	s.ScaleOverLifetime = func(p *twodeeparticles.Particle, t twodeeparticles.NormalizedDuration, delta time.Duration) twodeeparticles.Vector {
-		data := p.Data().(*bubbleData)
+	scale := twodeeparticles.ScaleFromTo(
+		twodeeparticles.VectorRange{
+			Min: twodeeparticles.Vector{startScale, startScale},
+			Max: twodeeparticles.Vector{startScale, startScale},
+		},
+		twodeeparticles.VectorRange{
+			Min: twodeeparticles.Vector{endScale - endScaleVariance/2.0, endScale - endScaleVariance/2.0},
+			Max: twodeeparticles.Vector{endScale + endScaleVariance/2.0, endScale + endScaleVariance/2.0},
+		},
+		twodeeparticles.Curve{})
 
+	s.ScaleOverLifetime = func(p *twodeeparticles.Particle, t twodeeparticles.NormalizedDuration, delta time.Duration) twodeeparticles.Vector {
 		s := t.Duration(p.Lifetime()).Seconds()
-		if s == 0 {
-			return twodeeparticles.Vector{startScale, startScale}
-		}
-
 		moveTime := p.Lifetime().Seconds() - fadeOutTime
 		if s > moveTime {
-			sc := (1.0-ease.OutSine((s-moveTime)/fadeOutTime))*(data.endScale-startScale) + startScale
-			return twodeeparticles.Vector{sc, sc}
+			return scale(p, twodeeparticles.NormalizedDuration(1.0-ease.OutSine((s-moveTime)/fadeOutTime)), delta)
 		}
 
-		sc := ease.OutSine(s/moveTime)*(data.endScale-startScale) + startScale
-		return twodeeparticles.Vector{sc, sc}
+		return scale(p, twodeeparticles.NormalizedDuration(ease.OutSine(s/moveTime)), delta)
 	}
 
 	s.ColorOverLifetime = func(p *twodeeparticles.Particle, t twodeeparticles.NormalizedDuration, delta time.Duration) color.Color {
@@ -258,21 +269,15 @@ func fountain(rand *rand.Rand) *twodeeparticles.ParticleSystem {
 	s.EmissionRateOverTime = constant(80.0)
 	s.LifetimeOverTime = constantDuration(5 * time.Second)
 
-	s.VelocityOverLifetime = func(p *twodeeparticles.Particle, t twodeeparticles.NormalizedDuration, delta time.Duration) twodeeparticles.Vector {
-		var v twodeeparticles.Vector
-
-		if t == 0 {
-			a := 2.0 * math.Pi * randomValue(80.0, 100.0, rand) / 360.0
-			s := randomValue(315.0-25.0, 315.0+25.0, rand)
-			dir := angleToDirection(a)
-			v = dir.Multiply(s)
-		} else {
-			v = p.Velocity()
-		}
-
-		return v.Add(gravity.Multiply(delta.Seconds()))
+	s.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos twodeeparticles.Vector) twodeeparticles.Vector {
+		a := 2.0 * math.Pi * randomValue(80.0, 100.0, rand) / 360.0
+		s := randomValue(315.0-25.0, 315.0+25.0, rand)
+		dir := angleToDirection(a)
+		return dir.Multiply(s)
 	}
 
+	s.AccelerationOverLifetime = particleConstantVector(gravity)
+
 	s.ScaleOverLifetime = particleConstantVector(twodeeparticles.Vector{0.2, 0.2})
 
 	s.ColorOverLifetime = func(p *twodeeparticles.Particle, t twodeeparticles.NormalizedDuration, delta time.Duration) color.Color {
@@ -305,7 +310,7 @@ func vortex(rand *rand.Rand) *twodeeparticles.ParticleSystem {
 		return 15.0
 	}
 
-	s.LifetimeOverTime = constantDuration(24 * time.Hour)
+	s.LifetimeOverTime = constantDuration(twodeeparticles.InfiniteLifetime)
 
 	s.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) twodeeparticles.Vector {
 		a := randomValue(0.0, 360.0, rand)
@@ -314,13 +319,13 @@ func vortex(rand *rand.Rand) *twodeeparticles.ParticleSystem {
 		return dir.Multiply(dist)
 	}
 
-	s.VelocityOverLifetime = func(p *twodeeparticles.Particle, t twodeeparticles.NormalizedDuration, delta time.Duration) twodeeparticles.Vector {
-		if t == 0 {
-			dir := p.Position().Normalize()
-			dir = rotate(dir, 2.0*math.Pi*-90.0/360.0)
-			return dir.Multiply(200.0)
-		}
+	s.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos twodeeparticles.Vector) twodeeparticles.Vector {
+		dir := spawnPos.Normalize()
+		dir = rotate(dir, 2.0*math.Pi*-90.0/360.0)
+		return dir.Multiply(200.0)
+	}
 
+	s.VelocityOverLifetime = func(p *twodeeparticles.Particle, t twodeeparticles.NormalizedDuration, delta time.Duration) twodeeparticles.Vector {
 		v := p.Velocity()
 		s := v.Magnitude()
 		dir := v.Normalize()
@@ -330,7 +335,7 @@ func vortex(rand *rand.Rand) *twodeeparticles.ParticleSystem {
 	}
 
 	s.ScaleOverLifetime = func(p *twodeeparticles.Particle, t twodeeparticles.NormalizedDuration, delta time.Duration) twodeeparticles.Vector {
-		if t == 0 {
+		if p.Age() == 0 {
 			s := randomValue(0.1, 0.7, rand)
 			return twodeeparticles.Vector{s, s}
 		}
@@ -339,7 +344,7 @@ func vortex(rand *rand.Rand) *twodeeparticles.ParticleSystem {
 	}
 
 	s.ColorOverLifetime = func(p *twodeeparticles.Particle, t twodeeparticles.NormalizedDuration, delta time.Duration) color.Color {
-		if t == 0 {
+		if p.Age() == 0 {
 			return color.RGBA{255, 255, 255, uint8(randomValue(minAlpha, 1.0, rand) * 255.0)}
 		}
 
@@ -354,7 +359,7 @@ func boids(rand *rand.Rand) *twodeeparticles.ParticleSystem {
 
 	s.MaxParticles = 75
 
-	s.LifetimeOverTime = constantDuration(24 * time.Hour)
+	s.LifetimeOverTime = constantDuration(twodeeparticles.InfiniteLifetime)
 
 	s.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) twodeeparticles.Vector {
 		x := randomValue(-windowWidth*0.8/2.0, windowWidth*0.8/2.0, rand)
@@ -423,6 +428,97 @@ func boids(rand *rand.Rand) *twodeeparticles.ParticleSystem {
 	return s
 }
 
+func smoke(rand *rand.Rand) *twodeeparticles.ParticleSystem {
+	s := twodeeparticles.NewSystem()
+
+	s.MaxParticles = 400
+
+	s.EmissionRateOverTime = constant(60.0)
+	s.LifetimeOverTime = constantDuration(6 * time.Second)
+
+	// start with a fully developed plume of smoke
+	s.Prewarm = 6 * time.Second
+
+	s.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) twodeeparticles.Vector {
+		return twodeeparticles.Vector{randomValue(-20.0, 20.0, rand), 0}
+	}
+
+	noise := twodeeparticles.Noise{
+		Seed:      rand.Uint64(),
+		Frequency: 0.01,
+		Octaves:   3,
+	}
+
+	s.VelocityOverLifetime = func(p *twodeeparticles.Particle, t twodeeparticles.NormalizedDuration, delta time.Duration) twodeeparticles.Vector {
+		pos := p.Position()
+		age := p.Age().Seconds()
+
+		// drift sideways according to noise that slowly changes over time
+		drift := noise.At2D(pos.X, pos.Y+age*30.0)*2.0 - 1.0
+
+		return twodeeparticles.Vector{drift * 80.0, -60.0 - 20.0*p.Random(0)}
+	}
+
+	s.ScaleOverLifetime = func(p *twodeeparticles.Particle, t twodeeparticles.NormalizedDuration, delta time.Duration) twodeeparticles.Vector {
+		sc := 0.2 + float64(t)*0.8
+		return twodeeparticles.Vector{sc, sc}
+	}
+
+	s.ColorOverLifetime = func(p *twodeeparticles.Particle, t twodeeparticles.NormalizedDuration, delta time.Duration) color.Color {
+		return color.RGBA{255, 255, 255, uint8((1.0 - float64(t)) * minAlpha * 255.0)}
+	}
+
+	return s
+}
+
+func stir(rand *rand.Rand) *twodeeparticles.ParticleSystem {
+	s := twodeeparticles.NewSystem()
+
+	s.MaxParticles = 300
+
+	s.LifetimeOverTime = constantDuration(twodeeparticles.InfiniteLifetime)
+
+	s.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) twodeeparticles.Vector {
+		x := randomValue(-windowWidth*0.8/2.0, windowWidth*0.8/2.0, rand)
+		y := randomValue(-windowHeight*0.8/2.0, windowHeight*0.8/2.0, rand)
+		return twodeeparticles.Vector{x, y}
+	}
+
+	// slow particles down over time, so that they come to rest when the mouse is not moving
+	s.VelocityOverLifetime = func(p *twodeeparticles.Particle, t twodeeparticles.NormalizedDuration, delta time.Duration) twodeeparticles.Vector {
+		return p.Velocity().Multiply(math.Pow(0.3, delta.Seconds()))
+	}
+
+	s.Attractors = []twodeeparticles.InteractiveAttractor{
+		{
+			PositionFunc: func() (twodeeparticles.Vector, bool) {
+				x, y := ebiten.CursorPosition()
+				return s.ScreenToLocal(twodeeparticles.Vector{float64(x), float64(y)}), true
+			},
+			Strength: 800.0,
+			Radius:   150.0,
+			Falloff:  twodeeparticles.FalloffLinear,
+		},
+	}
+
+	s.Separation = twodeeparticles.Separation{Radius: 15.0, Strength: 200.0}
+
+	s.ScaleOverLifetime = particleConstantVector(twodeeparticles.Vector{0.2, 0.2})
+
+	s.Spawn(s.MaxParticles)
+
+	return s
+}
+
+func fireworks(rand *rand.Rand) *twodeeparticles.ParticleSystem {
+	f := twodeeparticles.NewFirework(2000, rand)
+
+	s := f.System()
+	s.EmissionRateOverTime = constant(1.0)
+
+	return s
+}
+
 func constant(c float64) twodeeparticles.ValueOverTimeFunc {
 	return func(d time.Duration, delta time.Duration) float64 {
 		return c
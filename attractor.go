@@ -0,0 +1,73 @@
+package twodeeparticles
+
+import "time"
+
+// An InteractiveAttractor pulls particles towards a position that is queried on every Update, for example the mouse
+// position while a mouse button is held down. This allows the player to stir particles without having to apply
+// forces manually on every frame.
+type InteractiveAttractor struct {
+	// PositionFunc returns the attractor's position, relative to the system's origin, and whether the attractor is
+	// currently active. For example, to attract particles to the mouse position, PositionFunc would return the mouse
+	// position minus the position the system is drawn at, and whether the mouse button is pressed.
+	PositionFunc func() (Vector, bool)
+
+	// Strength is the velocity change of particles at the attractor's position, in arbitrary units per second squared.
+	// A negative strength repels particles.
+	Strength float64
+
+	// Radius is the distance within which particles are attracted. If Radius is 0, all particles are attracted.
+	Radius float64
+
+	// Falloff specifies how the strength decreases with the distance from the attractor's position. Falloff
+	// is ignored if Radius is 0.
+	Falloff Falloff
+}
+
+// applyAttractors changes the velocities of particles according to Attractors.
+func (sys *ParticleSystem) applyAttractors(now time.Time) {
+	if len(sys.Attractors) == 0 {
+		return
+	}
+
+	sec := now.Sub(sys.lastUpdateTime).Seconds()
+	if sec <= 0 {
+		return
+	}
+
+	for _, a := range sys.Attractors {
+		if a.PositionFunc == nil {
+			continue
+		}
+
+		pos, ok := a.PositionFunc()
+		if !ok {
+			continue
+		}
+
+		for _, p := range sys.particles {
+			if !p.isAlive {
+				continue
+			}
+
+			diff := sys.offset(p.position, pos)
+			dist := diff.Magnitude()
+
+			f := 1.0
+
+			if a.Radius > 0 {
+				if dist > a.Radius {
+					continue
+				}
+
+				f = a.Falloff.factor(dist, a.Radius)
+			}
+
+			dir, ok := diff.TryNormalize()
+			if !ok {
+				continue
+			}
+
+			p.velocity = p.velocity.Add(dir.Multiply(a.Strength * f * sec))
+		}
+	}
+}
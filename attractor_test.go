@@ -0,0 +1,71 @@
+package twodeeparticles
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestParticleSystem_Update_Attractors(t *testing.T) {
+	is := is.New(t)
+
+	positions := []Vector{{0, 0}, {5, 0}, {30, 0}}
+
+	sys := NewSystem()
+	sys.MaxParticles = len(positions)
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return InfiniteLifetime
+	}
+
+	idx := 0
+	sys.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) Vector {
+		pos := positions[idx]
+		idx++
+
+		return pos
+	}
+
+	pressed := false
+
+	sys.Attractors = []InteractiveAttractor{
+		{
+			PositionFunc: func() (Vector, bool) {
+				return Vector{10, 0}, pressed
+			},
+			Strength: 10.0,
+			Radius:   20.0,
+			Falloff:  FalloffLinear,
+		},
+	}
+
+	sys.Spawn(len(positions))
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(100 * time.Millisecond)
+	sys.Update(now)
+
+	velocities := func() []Vector {
+		var v []Vector
+
+		sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+			v = append(v, p.Velocity())
+		}, now)
+
+		return v
+	}
+
+	is.Equal(velocities(), []Vector{ZeroVector, ZeroVector, ZeroVector})
+
+	pressed = true
+
+	now = now.Add(100 * time.Millisecond)
+	sys.Update(now)
+
+	v := velocities()
+	is.True(approxEqualVector(v[0], Vector{0.5, 0}))
+	is.True(approxEqualVector(v[1], Vector{0.75, 0}))
+	is.Equal(v[2], ZeroVector)
+}
@@ -0,0 +1,78 @@
+package twodeeparticles
+
+import (
+	"math"
+	"time"
+)
+
+// A ClampShape is a shape that can clamp points into itself (see EmissionBoundsMode.)
+type ClampShape interface {
+	Shape
+
+	// Clamp returns the point inside of the shape that is nearest to v. If v is inside of the shape, Clamp returns v.
+	Clamp(v Vector) Vector
+}
+
+// EmissionBoundsMode specifies what happens to particles whose initial positions are outside of
+// ParticleSystem.EmissionBounds, after all resamples have been used up.
+type EmissionBoundsMode int
+
+const (
+	// BoundsReject kills particles whose initial positions are outside of the bounds immediately.
+	BoundsReject EmissionBoundsMode = iota
+
+	// BoundsClamp moves the initial positions of particles into the bounds, if the bounds are a ClampShape.
+	// Otherwise, particles are rejected like with BoundsReject.
+	BoundsClamp
+)
+
+var (
+	_ ClampShape = Rect{}
+	_ ClampShape = Circle{}
+)
+
+// Clamp returns the point inside of r that is nearest to v. Since points on r's Max edges are not inside of r,
+// points beyond those edges are moved to just inside of them.
+func (r Rect) Clamp(v Vector) Vector {
+	return Vector{
+		math.Max(r.Min.X, math.Min(v.X, math.Nextafter(r.Max.X, math.Inf(-1)))),
+		math.Max(r.Min.Y, math.Min(v.Y, math.Nextafter(r.Max.Y, math.Inf(-1)))),
+	}
+}
+
+// Clamp returns the point inside of c that is nearest to v. If c's radius is 0 or less, it returns c's center.
+func (c Circle) Clamp(v Vector) Vector {
+	if c.Contains(v) {
+		return v
+	}
+
+	dir, ok := v.Add(c.Center.Multiply(-1.0)).TryNormalize()
+	if !ok {
+		return c.Center
+	}
+
+	return c.Center.Add(dir.Multiply(math.Max(math.Nextafter(c.Radius, 0.0), 0.0)))
+}
+
+// constrainEmissionPosition constrains part's initial position to EmissionBounds. The position is resampled using
+// EmissionPositionOverTime if needed. It returns false if part should be rejected.
+func (sys *ParticleSystem) constrainEmissionPosition(part *Particle, d time.Duration, delta time.Duration) bool {
+	if sys.EmissionBounds == nil {
+		return true
+	}
+
+	for i := 0; !sys.EmissionBounds.Contains(part.position); i++ {
+		if i >= sys.EmissionResamples || sys.EmissionPositionOverTime == nil {
+			if c, ok := sys.EmissionBounds.(ClampShape); ok && sys.EmissionBoundsMode == BoundsClamp {
+				part.position = c.Clamp(part.position)
+				return true
+			}
+
+			return false
+		}
+
+		part.position = sys.EmissionPositionOverTime(d, delta)
+	}
+
+	return true
+}
@@ -0,0 +1,98 @@
+package twodeeparticles
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestRect_Clamp(t *testing.T) {
+	is := is.New(t)
+
+	r := Rect{Vector{0, 0}, Vector{10, 10}}
+
+	is.Equal(r.Clamp(Vector{5, 5}), Vector{5, 5})
+	is.Equal(r.Clamp(Vector{-5, 5}), Vector{0, 5})
+	is.True(r.Contains(r.Clamp(Vector{15, 20})))
+	is.True(approxEqualVector(r.Clamp(Vector{15, 20}), Vector{10, 10}))
+}
+
+func TestCircle_Clamp(t *testing.T) {
+	is := is.New(t)
+
+	c := Circle{Center: Vector{1, 1}, Radius: 2}
+
+	is.Equal(c.Clamp(Vector{2, 1}), Vector{2, 1})
+	is.True(c.Contains(c.Clamp(Vector{1, 10})))
+	is.True(approxEqualVector(c.Clamp(Vector{1, 10}), Vector{1, 3}))
+
+	c = Circle{Center: Vector{1, 1}}
+
+	is.Equal(c.Clamp(Vector{1, 1}), Vector{1, 1})
+	is.Equal(c.Clamp(Vector{5, 1}), Vector{1, 1})
+
+	c.Radius = -2
+
+	is.Equal(c.Clamp(Vector{1, 1}), Vector{1, 1})
+	is.Equal(c.Clamp(Vector{5, 1}), Vector{1, 1})
+}
+
+func TestParticleSystem_Update_EmissionBounds(t *testing.T) {
+	is := is.New(t)
+
+	positions := []Vector{{20, 0}, {30, 0}, {5, 0}}
+	calls := 0
+
+	sys := NewSystem()
+	sys.MaxParticles = 10
+	sys.EmissionBounds = Rect{Vector{0, -10}, Vector{10, 10}}
+	sys.EmissionResamples = 2
+	sys.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) Vector {
+		p := positions[calls%len(positions)]
+		calls++
+
+		return p
+	}
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	is.Equal(calls, 3)
+	is.Equal(sys.NumParticles(), 1)
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.Equal(p.Position(), Vector{5, 0})
+	}, now)
+}
+
+func TestParticleSystem_Update_EmissionBoundsMode(t *testing.T) {
+	is := is.New(t)
+
+	for _, mode := range []EmissionBoundsMode{BoundsReject, BoundsClamp} {
+		sys := NewSystem()
+		sys.MaxParticles = 10
+		sys.EmissionBounds = Circle{Radius: 10}
+		sys.EmissionBoundsMode = mode
+		sys.EmissionResamples = 3
+		sys.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) Vector {
+			return Vector{20, 0}
+		}
+		sys.Spawn(5)
+
+		now := time.Now()
+		sys.Update(now)
+
+		if mode == BoundsReject {
+			is.Equal(sys.NumParticles(), 0)
+			continue
+		}
+
+		is.Equal(sys.NumParticles(), 5)
+
+		sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+			is.True(approxEqualVector(p.Position(), Vector{10, 0}))
+		}, now)
+	}
+}
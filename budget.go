@@ -0,0 +1,89 @@
+package twodeeparticles
+
+import (
+	"sort"
+	"time"
+)
+
+// budgetCheckInterval is the number of particles updated by UpdateBudget between checks of the elapsed time.
+const budgetCheckInterval = 32
+
+// UpdateBudget updates the system like Update, but stops updating particles when budget has been used up, measured
+// in wall-clock time. The remaining particles are updated by the next calls to UpdateBudget. This can be used for
+// massive ambient effects that must never take too much of a frame's time. It returns whether all particles have been
+// updated, that is, whether the update round is complete.
+//
+// Particles that are not updated during a call are frozen in place until they are updated in a later call, and then
+// catch up in one step. With a budget that is too small, the effect will appear to run at a lower frame rate, and
+// different parts of the effect may visibly move out of step. New particles are only spawned, and system-wide effects
+// such as Separation are only applied, at the start of each update round. Particles are updated in small batches,
+// and at least one batch is updated per call, even if budget is 0.
+//
+// UpdateBudget should not be mixed with Update. Calling Update starts a new update round.
+//
+// If UpdateBudget is called while the system's particles are being iterated over (for example, from a function passed
+// to ForEachParticle), the update is deferred until the iteration has finished, and UpdateBudget returns false.
+func (sys *ParticleSystem) UpdateBudget(now time.Time, budget time.Duration) bool {
+	if sys.deferIfIterating(func() { sys.UpdateBudget(now, budget) }) {
+		return false
+	}
+
+	start := time.Now()
+
+	roundStart := sys.budgetLastID == 0
+
+	now, ok := sys.prepareUpdate(now, roundStart)
+	if !ok {
+		return true
+	}
+
+	defer sys.finishUpdate()
+
+	if roundStart {
+		sys.beforeUpdate(now)
+		sys.removeDeadParticles(now, !sys.stopped)
+		sys.spawnParticles(now)
+		sys.computeDensities()
+
+		sys.lastUpdateTime = now
+	}
+
+	if !sys.updateParticlesBudget(now, start, budget) {
+		return false
+	}
+
+	sys.budgetLastID = 0
+	sys.removeDeadParticles(now, !sys.stopped)
+	sys.checkComplete(now)
+
+	return true
+}
+
+// updateParticlesBudget updates particles following the one most recently updated in the current update round,
+// until budget has been used up. It returns whether all particles have been updated. Since particles are kept in
+// ID order, the position is tracked by ID, so that it stays valid if particles are removed between calls.
+func (sys *ParticleSystem) updateParticlesBudget(now time.Time, start time.Time, budget time.Duration) bool {
+	sys.beginIteration()
+	defer sys.endIteration()
+
+	idx := sort.Search(len(sys.particles), func(i int) bool {
+		return sys.particles[i].id > sys.budgetLastID
+	})
+
+	for num := 1; idx < len(sys.particles); num++ {
+		p := sys.particles[idx]
+		idx++
+
+		sys.budgetLastID = p.id
+
+		if p.isAlive {
+			p.updateWithTimers(now)
+		}
+
+		if num%budgetCheckInterval == 0 && time.Since(start) >= budget {
+			break
+		}
+	}
+
+	return idx >= len(sys.particles)
+}
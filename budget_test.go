@@ -0,0 +1,172 @@
+package twodeeparticles
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestParticleSystem_UpdateBudget(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 100
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return InfiniteLifetime
+	}
+
+	sys.Spawn(100)
+
+	now := time.Now()
+	is.True(!sys.UpdateBudget(now, 0))
+	is.Equal(sys.NumParticles(), 100)
+
+	// a budget of 0 updates one batch per call
+	now = now.Add(time.Second)
+	is.True(!sys.UpdateBudget(now, 0))
+	is.True(!sys.UpdateBudget(now, 0))
+	is.True(sys.UpdateBudget(now, 0))
+
+	// the first batch has been updated at the start of the next round
+	now = now.Add(time.Second)
+	is.True(!sys.UpdateBudget(now, 0))
+
+	var ages []time.Duration
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		ages = append(ages, p.Age())
+	}, now)
+
+	is.Equal(ages[0], 2*time.Second)
+	is.Equal(ages[budgetCheckInterval-1], 2*time.Second)
+	is.Equal(ages[budgetCheckInterval], time.Second)
+	is.Equal(ages[99], time.Second)
+
+	// a large budget updates everything at once
+	is.True(sys.UpdateBudget(now, time.Hour))
+
+	ages = nil
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		ages = append(ages, p.Age())
+	}, now)
+
+	is.Equal(ages[99], 2*time.Second)
+}
+
+func TestParticleSystem_UpdateBudget_Culled(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 100
+	sys.CatchUpStep = 250 * time.Millisecond
+	sys.EmissionRateOverTime = func(d time.Duration, delta time.Duration) float64 {
+		return 10.0
+	}
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 10 * time.Second
+	}
+
+	now := time.Now()
+	is.True(sys.UpdateBudget(now, time.Hour))
+
+	sys.Culled = true
+
+	now = now.Add(2 * time.Second)
+	is.True(sys.UpdateBudget(now, time.Hour))
+	is.Equal(sys.NumParticles(), 0)
+
+	sys.Culled = false
+
+	now = now.Add(100 * time.Millisecond)
+	is.True(sys.UpdateBudget(now, time.Hour))
+	is.Equal(sys.NumParticles(), 21)
+}
+
+func TestParticleSystem_UpdateBudget_Stopped(t *testing.T) {
+	is := is.New(t)
+
+	completed := false
+
+	sys := NewSystem()
+	sys.MaxParticles = 100
+	sys.RespawnMode = RespawnAtEmissionPosition
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 1 * time.Second
+	}
+	sys.CompleteFunc = func() {
+		completed = true
+	}
+
+	sys.Spawn(5)
+
+	now := time.Now()
+	is.True(sys.UpdateBudget(now, time.Hour))
+
+	sys.Stop(StopEmitting)
+
+	now = now.Add(1500 * time.Millisecond)
+	is.True(sys.UpdateBudget(now, time.Hour))
+
+	is.Equal(sys.NumParticles(), 0)
+	is.True(completed)
+}
+
+func TestParticleSystem_UpdateBudget_Compacted(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 100
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return InfiniteLifetime
+	}
+
+	updates := map[uint64]int{}
+
+	sys.UpdateFunc = func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		updates[p.ID()]++
+	}
+
+	sys.Spawn(100)
+
+	now := time.Now()
+	for done := false; !done; {
+		done = sys.UpdateBudget(now, 0)
+	}
+
+	updates = map[uint64]int{}
+
+	now = now.Add(time.Second)
+	is.True(!sys.UpdateBudget(now, 0))
+
+	other := NewSystem()
+	other.MaxParticles = 100
+
+	sys.TransferTo(other, func(p *Particle) bool {
+		return p.ID() <= 50
+	})
+
+	is.Equal(sys.NumParticles(), 50)
+
+	for done := false; !done; {
+		done = sys.UpdateBudget(now, 0)
+	}
+
+	for id := uint64(51); id <= 100; id++ {
+		is.Equal(updates[id], 1)
+	}
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.Equal(p.Age(), time.Second)
+	}, now)
+
+	// clearing the system in the middle of a round completes it
+	now = now.Add(time.Second)
+	is.True(!sys.UpdateBudget(now, 0))
+
+	sys.Stop(StopAndClear)
+
+	is.True(sys.UpdateBudget(now, 0))
+	is.Equal(sys.NumParticles(), 0)
+}
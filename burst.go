@@ -0,0 +1,73 @@
+package twodeeparticles
+
+import "time"
+
+// A Burst is a number of particles that are spawned at once (see ParticleSystem.SpawnBurst.)
+type Burst struct {
+	// Count is the number of particles to spawn.
+	Count int
+
+	// LifetimeStagger is the window that the lifetimes of the burst's particles are spread across. The lifetime
+	// of each particle is increased by a duration in the range [0,LifetimeStagger], so that the particles die
+	// gradually instead of all at once.
+	LifetimeStagger time.Duration
+
+	// StaggerMode specifies how the lifetimes of the burst's particles are spread across LifetimeStagger.
+	StaggerMode StaggerMode
+}
+
+// StaggerMode specifies how the lifetimes of a burst's particles are spread across a window.
+type StaggerMode int
+
+const (
+	// StaggerLinear spreads lifetimes evenly across the window, in the order the particles are spawned.
+	StaggerLinear StaggerMode = iota
+
+	// StaggerRandom spreads lifetimes randomly across the window.
+	StaggerRandom
+)
+
+// SpawnBurst spawns the particles of b on the next Update, regardless of EmissionRateOverTime.
+func (sys *ParticleSystem) SpawnBurst(b Burst) {
+	sys.bursts = append(sys.bursts, b)
+}
+
+func (sys *ParticleSystem) spawnBursts(now time.Time) {
+	for _, b := range sys.bursts {
+		for i := 0; i < b.Count; i++ {
+			num := sys.spawnParticle(now)
+			if num == 0 {
+				break
+			}
+
+			extra := b.stagger(i, sys.particles[len(sys.particles)-num].Random(randomBurstStagger))
+
+			for _, p := range sys.particles[len(sys.particles)-num:] {
+				if p.lifetime != InfiniteLifetime {
+					p.SetLifetime(p.lifetime + extra)
+				}
+			}
+		}
+	}
+
+	sys.bursts = sys.bursts[:0]
+}
+
+// stagger returns the lifetime extension of the i-th particle of b. r is a random value in the range [0.0,1.0).
+func (b Burst) stagger(i int, r float64) time.Duration {
+	if b.LifetimeStagger <= 0 {
+		return 0
+	}
+
+	switch b.StaggerMode {
+	case StaggerRandom:
+		return time.Duration(r * float64(b.LifetimeStagger))
+
+	default:
+		if b.Count <= 1 {
+			return 0
+		}
+
+		return time.Duration(float64(b.LifetimeStagger) * float64(i) / float64(b.Count-1))
+	}
+}
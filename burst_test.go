@@ -0,0 +1,68 @@
+package twodeeparticles
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestParticleSystem_SpawnBurst(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+
+	sys.MaxParticles = 10
+
+	sys.SpawnBurst(Burst{
+		Count:           5,
+		LifetimeStagger: 2 * time.Second,
+	})
+
+	now := time.Now()
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 5)
+
+	var lifetimes []time.Duration
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		lifetimes = append(lifetimes, p.Lifetime())
+	}, now)
+
+	is.Equal(lifetimes, []time.Duration{
+		1000 * time.Millisecond,
+		1500 * time.Millisecond,
+		2000 * time.Millisecond,
+		2500 * time.Millisecond,
+		3000 * time.Millisecond,
+	})
+
+	now = now.Add(1750 * time.Millisecond)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 3)
+}
+
+func TestParticleSystem_SpawnBurst_Random(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+
+	sys.MaxParticles = 10
+
+	sys.SpawnBurst(Burst{
+		Count:           20,
+		LifetimeStagger: 2 * time.Second,
+		StaggerMode:     StaggerRandom,
+	})
+
+	now := time.Now()
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 10)
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.True(p.Lifetime() >= 1*time.Second && p.Lifetime() <= 3*time.Second)
+	}, now)
+}
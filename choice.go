@@ -0,0 +1,66 @@
+package twodeeparticles
+
+import "math/rand"
+
+// A WeightedChoice is a list of items that can be sampled randomly, with each item having a relative probability
+// of being sampled.
+type WeightedChoice[T any] struct {
+	// Items are the items to choose from.
+	Items []T
+
+	// Weights are the relative probabilities of the items being sampled. If Weights is nil, all items are equally
+	// likely to be sampled. Otherwise, Weights must have the same length as Items.
+	Weights []float64
+}
+
+// Sample returns a random item from c. If c does not contain any items, it returns the zero value of T.
+func (c WeightedChoice[T]) Sample(rand *rand.Rand) T {
+	idx := c.SampleIndex(rand)
+	if idx < 0 {
+		var zero T
+		return zero
+	}
+
+	return c.Items[idx]
+}
+
+// SampleIndex returns the index of a random item from c. If c does not contain any items, it returns -1.
+func (c WeightedChoice[T]) SampleIndex(rand *rand.Rand) int {
+	if c.Weights == nil {
+		if len(c.Items) == 0 {
+			return -1
+		}
+
+		return rand.Intn(len(c.Items))
+	}
+
+	return sampleWeighted(len(c.Items), func(idx int) float64 {
+		return c.Weights[idx]
+	}, rand)
+}
+
+// sampleWeighted returns a random index in the range [0,num), with weight returning the relative probability of
+// each index. If num is 0, it returns -1.
+func sampleWeighted(num int, weight func(idx int) float64, rand *rand.Rand) int {
+	if num == 0 {
+		return -1
+	}
+
+	total := 0.0
+	for idx := 0; idx < num; idx++ {
+		total += weight(idx)
+	}
+
+	r := rand.Float64() * total
+
+	for idx := 0; idx < num; idx++ {
+		w := weight(idx)
+		if r < w {
+			return idx
+		}
+
+		r -= w
+	}
+
+	return num - 1
+}
@@ -0,0 +1,47 @@
+package twodeeparticles
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestWeightedChoice_Sample(t *testing.T) {
+	is := is.New(t)
+
+	rand := rand.New(rand.NewSource(1)) //nolint:gosec // no crypto
+
+	is.Equal(WeightedChoice[string]{}.Sample(rand), "")
+	is.Equal(WeightedChoice[string]{}.SampleIndex(rand), -1)
+
+	c := WeightedChoice[string]{
+		Items:   []string{"a", "b", "c"},
+		Weights: []float64{1.0, 0.0, 3.0},
+	}
+
+	counts := map[string]int{}
+	for i := 0; i < 4000; i++ {
+		counts[c.Sample(rand)]++
+	}
+
+	is.Equal(counts["b"], 0)
+	is.True(counts["a"] > 800 && counts["a"] < 1200)
+	is.True(counts["c"] > 2800 && counts["c"] < 3200)
+}
+
+func TestWeightedChoice_Sample_Uniform(t *testing.T) {
+	is := is.New(t)
+
+	rand := rand.New(rand.NewSource(1)) //nolint:gosec // no crypto
+
+	c := WeightedChoice[int]{Items: []int{1, 2}}
+
+	counts := map[int]int{}
+	for i := 0; i < 2000; i++ {
+		counts[c.Sample(rand)]++
+	}
+
+	is.True(counts[1] > 800 && counts[1] < 1200)
+	is.True(counts[2] > 800 && counts[2] < 1200)
+}
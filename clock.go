@@ -0,0 +1,177 @@
+package twodeeparticles
+
+import (
+	"math"
+	"time"
+)
+
+// advanceEpoch is the wall-clock time that Advance starts a system at that has not been updated yet.
+var advanceEpoch = time.Unix(0, 0)
+
+// A virtualClock converts the wall-clock times passed to a system into the system's own simulation times. Simulation
+// time advances like wall-clock time multiplied by the time scale, except while the system is paused.
+type virtualClock struct {
+	wallAnchor time.Time
+	simAnchor  time.Time
+	lastWall   time.Time
+	paused     bool
+	resuming   bool
+	scale      float64
+	scaleSet   bool
+}
+
+// init starts c at wall-clock time now, which is also the simulation time. Whether c is paused and its time scale
+// are kept, so that they can be set before the first update.
+func (c *virtualClock) init(now time.Time) {
+	c.wallAnchor = now
+	c.simAnchor = now
+	c.lastWall = now
+}
+
+// timeScale returns c's time scale.
+func (c *virtualClock) timeScale() float64 {
+	if !c.scaleSet {
+		return 1.0
+	}
+
+	return c.scale
+}
+
+// setTimeScale changes c's time scale as of the wall-clock time of the most recent advance.
+func (c *virtualClock) setTimeScale(f float64) {
+	if !c.paused && !c.resuming && !c.wallAnchor.IsZero() {
+		c.simAnchor = c.at(c.lastWall)
+		c.wallAnchor = c.lastWall
+	}
+
+	c.scale = f
+	c.scaleSet = true
+}
+
+// at returns the simulation time at wall-clock time now, without advancing c.
+func (c *virtualClock) at(now time.Time) time.Time {
+	if c.wallAnchor.IsZero() {
+		return now
+	}
+
+	if c.paused || c.resuming {
+		return c.simAnchor
+	}
+
+	return c.simAnchor.Add(scaleDuration(now.Sub(c.wallAnchor), c.timeScale()))
+}
+
+// advance advances c to wall-clock time now, and returns the simulation time at now.
+func (c *virtualClock) advance(now time.Time) time.Time {
+	if c.resuming {
+		c.wallAnchor = now
+		c.resuming = false
+	}
+
+	c.lastWall = now
+
+	return c.at(now)
+}
+
+// wall returns the approximate wall-clock time at simulation time sim.
+func (c *virtualClock) wall(sim time.Time) time.Time {
+	if c.wallAnchor.IsZero() {
+		return sim
+	}
+
+	s := c.timeScale()
+	if s <= 0.0 {
+		return c.lastWall
+	}
+
+	return c.lastWall.Add(scaleDuration(sim.Sub(c.at(c.lastWall)), 1.0/s))
+}
+
+// scaleDuration returns d multiplied by f.
+func scaleDuration(d time.Duration, f float64) time.Duration {
+	if f == 1.0 {
+		return d
+	}
+
+	return time.Duration(float64(d) * f)
+}
+
+// pause stops c at the wall-clock time of the most recent advance.
+func (c *virtualClock) pause() {
+	if c.paused {
+		return
+	}
+
+	c.simAnchor = c.at(c.lastWall)
+	c.wallAnchor = c.lastWall
+	c.paused = true
+}
+
+// resume lets c continue from the wall-clock time of the next advance, so that the time between pause and resume
+// does not count.
+func (c *virtualClock) resume() {
+	if !c.paused {
+		return
+	}
+
+	c.paused = false
+	c.resuming = true
+}
+
+// Pause pauses the system. While the system is paused, its simulation time does not advance, and Update does not
+// update particles. This can be used to pause the system while a game is paused, without having to stop calling
+// Update.
+//
+// The system is paused as of the most recent call to Update. If the system has not been updated yet, it is paused
+// right from the start.
+func (sys *ParticleSystem) Pause() {
+	sys.clock.pause()
+}
+
+// Resume resumes the system after it has been paused (see Pause.) Simulation time continues to advance as of the next
+// call to Update, so that the time the system was paused for does not cause a huge delta or a burst of particles.
+func (sys *ParticleSystem) Resume() {
+	sys.clock.resume()
+}
+
+// SetTimeScale sets the speed of the system's simulation time relative to wall-clock time, as of the most recent call
+// to Update. For example, a time scale of 0.5 runs the system in slow motion at half speed, and a time scale of 2.0
+// fast-forwards it at double speed. Durations passed to the system's functions (such as delta) are measured
+// in simulation time. Negative time scales are treated as 0.0, which freezes the system. The time scale is kept
+// when the system is Reset.
+//
+// The default time scale is 1.0.
+func (sys *ParticleSystem) SetTimeScale(f float64) {
+	sys.clock.setTimeScale(math.Max(f, 0.0))
+}
+
+// TimeScale returns the system's time scale (see SetTimeScale.)
+func (sys *ParticleSystem) TimeScale() float64 {
+	return sys.clock.timeScale()
+}
+
+// Paused returns whether the system is paused (see Pause.)
+func (sys *ParticleSystem) Paused() bool {
+	return sys.clock.paused
+}
+
+// Advance updates the system like Update, but advances it by dt instead of to a wall-clock time. This allows to drive
+// the system using an external clock, for example on headless servers, in tests, or in engines with their own clocks.
+// Advancing the system by the same sequence of durations is deterministic (see Deterministic.) dt is multiplied
+// by the time scale like wall-clock time (see SetTimeScale.)
+//
+// If the system has not been updated yet, Advance starts it at a fixed point in time. Functions that require a time,
+// such as ForEachParticle, should then be passed Now.
+func (sys *ParticleSystem) Advance(dt time.Duration) {
+	sys.Update(sys.Now().Add(dt))
+}
+
+// Now returns the wall-clock time of the most recent call to Update or Advance. If the system has not been updated yet,
+// Now returns the fixed point in time that Advance starts the system at.
+func (sys *ParticleSystem) Now() time.Time {
+	if sys.clock.lastWall.IsZero() {
+		return advanceEpoch
+	}
+
+	return sys.clock.lastWall
+}
@@ -0,0 +1,191 @@
+package twodeeparticles
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestParticleSystem_Pause(t *testing.T) {
+	is := is.New(t)
+
+	deltas := []time.Duration{}
+
+	sys := NewSystem()
+	sys.MaxParticles = 100
+	sys.EmissionRateOverTime = func(d time.Duration, delta time.Duration) float64 {
+		deltas = append(deltas, delta)
+		return 10.0
+	}
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 10 * time.Second
+	}
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 10)
+
+	sys.Pause()
+	is.True(sys.Paused())
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 10)
+	is.Equal(sys.Duration(now), 1*time.Second)
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.Equal(t, NormalizedDuration(0.0))
+		is.Equal(delta, time.Duration(0))
+	}, now)
+
+	// not calling Update for a while
+	now = now.Add(1 * time.Minute)
+
+	sys.Resume()
+	is.True(!sys.Paused())
+
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 10)
+	is.Equal(sys.Duration(now), 1*time.Second)
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 20)
+	is.Equal(sys.Duration(now), 2*time.Second)
+	is.Equal(deltas, []time.Duration{0, 1 * time.Second, 0, 1 * time.Second})
+}
+
+func TestParticleSystem_Pause_BeforeUpdate(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 100
+	sys.EmissionRateOverTime = func(d time.Duration, delta time.Duration) float64 {
+		return 10.0
+	}
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 10 * time.Second
+	}
+
+	sys.Pause()
+	is.True(sys.Paused())
+
+	now := time.Now()
+	sys.Update(now)
+
+	is.True(sys.Paused())
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 0)
+	is.Equal(sys.Duration(now), time.Duration(0))
+
+	sys.Resume()
+
+	now = now.Add(1 * time.Minute)
+	sys.Update(now)
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 10)
+	is.Equal(sys.Duration(now), 1*time.Second)
+}
+
+func TestParticleSystem_SetTimeScale(t *testing.T) {
+	is := is.New(t)
+
+	deltas := []time.Duration{}
+
+	sys := NewSystem()
+	sys.MaxParticles = 100
+	sys.SetTimeScale(0.5)
+	sys.EmissionRateOverTime = func(d time.Duration, delta time.Duration) float64 {
+		deltas = append(deltas, delta)
+		return 10.0
+	}
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 10 * time.Second
+	}
+
+	is.Equal(sys.TimeScale(), 0.5)
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(2 * time.Second)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 10)
+	is.Equal(sys.Duration(now), 1*time.Second)
+
+	sys.SetTimeScale(2.0)
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 30)
+	is.Equal(sys.Duration(now), 3*time.Second)
+	is.Equal(deltas, []time.Duration{0, 1 * time.Second, 2 * time.Second})
+
+	sys.SetTimeScale(-1.0)
+	is.Equal(sys.TimeScale(), 0.0)
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	is.Equal(sys.Duration(now), 3*time.Second)
+
+	sys.Reset()
+	is.Equal(sys.TimeScale(), 0.0)
+}
+
+func TestParticleSystem_Advance(t *testing.T) {
+	is := is.New(t)
+
+	run := func() []Vector {
+		sys := NewSystem()
+		sys.MaxParticles = 100
+		sys.Deterministic = true
+		sys.EmissionRateOverTime = func(d time.Duration, delta time.Duration) float64 {
+			return 10.0
+		}
+		sys.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+			return Vector{d.Seconds(), 1.0}
+		}
+		sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+			return 10 * time.Second
+		}
+
+		sys.Advance(0)
+
+		is.Equal(sys.Now(), advanceEpoch)
+
+		for i := 0; i < 10; i++ {
+			sys.Advance(100 * time.Millisecond)
+		}
+
+		is.Equal(sys.Now(), advanceEpoch.Add(1*time.Second))
+		is.Equal(sys.Duration(sys.Now()), 1*time.Second)
+		is.Equal(sys.NumParticles(), 10)
+
+		pos := []Vector{}
+
+		sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+			pos = append(pos, p.Position())
+		}, sys.Now())
+
+		return pos
+	}
+
+	is.Equal(run(), run())
+}
@@ -0,0 +1,161 @@
+package twodeeparticles
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// A Confetti is a preset that simulates confetti pieces being launched, tumbling, and falling down.
+// Pieces tumble around their Y axis using a FakeSpin, which makes them flip over when their X scale
+// becomes negative.
+//
+// The Data of a confetti particle is a *ConfettiPiece.
+type Confetti struct {
+	// Palette is the palette that the colors of pieces are randomly chosen from.
+	Palette Palette
+
+	// Direction is the direction that pieces are launched into, in radians.
+	Direction float64
+
+	// Spread is the width of the cone around Direction that pieces are launched into, in radians.
+	Spread float64
+
+	// Speed is the launch speed of pieces, in arbitrary units per second.
+	Speed float64
+
+	// SpeedVariance is the maximum random deviation from Speed, in arbitrary units per second.
+	SpeedVariance float64
+
+	// Gravity is the acceleration of pieces, in arbitrary units per second squared.
+	Gravity Vector
+
+	// Drag is the fraction of velocity that pieces lose per second, in the range [0.0,1.0].
+	Drag float64
+
+	// Size is the scale of pieces.
+	Size float64
+
+	// FlutterFrequency is the maximum frequency of pieces tumbling, in Hertz.
+	FlutterFrequency float64
+
+	// SpinSpeed is the maximum angular velocity of pieces, in radians per second.
+	SpinSpeed float64
+
+	// Lifetime is the lifetime of pieces. Pieces fade out during the last 20% of their lifetime.
+	Lifetime time.Duration
+
+	sys  *ParticleSystem
+	rand *rand.Rand
+}
+
+// A ConfettiPiece is the data attached to a confetti particle.
+type ConfettiPiece struct {
+	spin float64
+}
+
+// NewConfetti returns a new confetti effect. To launch pieces, use Burst.
+func NewConfetti(maxParticles int, rand *rand.Rand) *Confetti {
+	c := Confetti{
+		Palette: Palette{
+			Colors: []color.Color{
+				color.RGBA{0xe6, 0x39, 0x46, 0xff},
+				color.RGBA{0xf4, 0xa2, 0x61, 0xff},
+				color.RGBA{0xe9, 0xc4, 0x6a, 0xff},
+				color.RGBA{0x2a, 0x9d, 0x8f, 0xff},
+				color.RGBA{0x45, 0x7b, 0x9d, 0xff},
+				color.RGBA{0x9b, 0x5d, 0xe5, 0xff},
+			},
+		},
+		Direction:        -math.Pi / 2.0,
+		Spread:           math.Pi / 3.0,
+		Speed:            400.0,
+		SpeedVariance:    150.0,
+		Gravity:          Vector{0.0, 300.0},
+		Drag:             0.8,
+		Size:             0.2,
+		FlutterFrequency: 3.0,
+		SpinSpeed:        2.0 * math.Pi,
+		Lifetime:         3 * time.Second,
+		sys:              NewSystem(),
+		rand:             rand,
+	}
+
+	c.sys.MaxParticles = maxParticles
+	c.sys.LifetimeOverTime = c.lifetime
+	c.sys.InitialVelocityOverTime = c.initialVelocity
+	c.sys.DataOverLifetime = c.data
+	c.sys.VelocityOverLifetime = c.velocity
+	c.sys.RotationOverLifetime = c.rotation
+	c.sys.ScaleOverLifetime = c.scale
+	c.sys.ColorOverLifetime = c.color
+
+	return &c
+}
+
+// System returns the particle system that simulates c.
+func (c *Confetti) System() *ParticleSystem {
+	return c.sys
+}
+
+// Burst launches num pieces on the next Update.
+func (c *Confetti) Burst(num int) {
+	c.sys.Spawn(num)
+}
+
+func (c *Confetti) lifetime(d time.Duration, delta time.Duration) time.Duration {
+	return c.Lifetime
+}
+
+func (c *Confetti) initialVelocity(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+	a := c.Direction + (c.rand.Float64()-0.5)*c.Spread
+	s := c.Speed + (c.rand.Float64()*2.0-1.0)*c.SpeedVariance
+
+	return VectorFromAngle(a).Multiply(s)
+}
+
+func (c *Confetti) data(old any, t NormalizedDuration, delta time.Duration) any {
+	if old != nil {
+		return old
+	}
+
+	piece := ConfettiPiece{
+		spin: (c.rand.Float64()*2.0 - 1.0) * c.SpinSpeed,
+	}
+
+	return &piece
+}
+
+func (c *Confetti) velocity(p *Particle, t NormalizedDuration, delta time.Duration) Vector {
+	sec := delta.Seconds()
+	v := p.Velocity().Add(c.Gravity.Multiply(sec))
+
+	return v.Multiply(math.Max(0.0, 1.0-c.Drag*sec))
+}
+
+func (c *Confetti) rotation(p *Particle, t NormalizedDuration, delta time.Duration) float64 {
+	return p.Data().(*ConfettiPiece).spin //nolint:forcetypeassert // we know this is a *ConfettiPiece
+}
+
+func (c *Confetti) scale(p *Particle, t NormalizedDuration, delta time.Duration) Vector {
+	tumble := FakeSpin{
+		Speed:         Vector{0.0, 2.0 * math.Pi * c.FlutterFrequency},
+		SpeedVariance: 0.5,
+		RandomPhase:   true,
+	}
+
+	return tumble.Factors(p).Multiply(c.Size)
+}
+
+func (c *Confetti) color(p *Particle, t NormalizedDuration, delta time.Duration) color.Color {
+	if !p.updated {
+		return c.Palette.Sample(c.rand)
+	}
+
+	if t < 0.8 {
+		return p.StartColor()
+	}
+
+	return fadeColor(p.StartColor(), (1.0-float64(t))/0.2)
+}
@@ -0,0 +1,61 @@
+package twodeeparticles
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestConfetti_Burst(t *testing.T) {
+	is := is.New(t)
+
+	c := NewConfetti(100, rand.New(rand.NewSource(1))) //nolint:gosec // no crypto
+	c.Palette = Palette{Colors: []color.Color{color.RGBA{0x12, 0x23, 0x34, 0xff}}}
+	c.Spread = math.Pi / 4.0
+
+	c.Burst(50)
+
+	now := time.Now()
+	c.System().Update(now)
+
+	is.Equal(c.System().NumParticles(), 50)
+
+	c.System().ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.Equal(p.Color(), color.RGBA{0x12, 0x23, 0x34, 0xff})
+
+		a := math.Atan2(p.Velocity().Y, p.Velocity().X)
+		is.True(a >= c.Direction-c.Spread/2.0 && a <= c.Direction+c.Spread/2.0)
+	}, now)
+}
+
+func TestConfetti_Flutter(t *testing.T) {
+	is := is.New(t)
+
+	c := NewConfetti(100, rand.New(rand.NewSource(1))) //nolint:gosec // no crypto
+	c.Burst(50)
+
+	now := time.Now()
+	c.System().Update(now)
+
+	flipped := false
+
+	for i := 0; i < 20; i++ {
+		now = now.Add(50 * time.Millisecond)
+		c.System().Update(now)
+
+		c.System().ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+			is.True(math.Abs(p.Scale().X) <= c.Size)
+			is.Equal(p.Scale().Y, c.Size)
+
+			if p.Scale().X < 0 {
+				flipped = true
+			}
+		}, now)
+	}
+
+	is.True(flipped)
+}
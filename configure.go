@@ -0,0 +1,29 @@
+package twodeeparticles
+
+// A ConfigureFunc is a function that changes the configuration of sys (see ParticleSystem.Configure.)
+type ConfigureFunc func(sys *ParticleSystem)
+
+// Configure schedules fun to be called at the start of the next Update (or UpdateBudget update round), before
+// the system is simulated. This allows to safely change the system's configuration at runtime, for example to change
+// gravity when entering water, without interfering with an update that is currently running. Functions scheduled
+// using multiple calls to Configure are called in order, so all of their changes are applied at once.
+//
+// Configure may be called from any goroutine, and from functions called during Update.
+func (sys *ParticleSystem) Configure(fun ConfigureFunc) {
+	sys.configureMutex.Lock()
+	defer sys.configureMutex.Unlock()
+
+	sys.configureFuncs = append(sys.configureFuncs, fun)
+}
+
+// applyConfigure calls all functions scheduled using Configure.
+func (sys *ParticleSystem) applyConfigure() {
+	sys.configureMutex.Lock()
+	funcs := sys.configureFuncs
+	sys.configureFuncs = nil
+	sys.configureMutex.Unlock()
+
+	for _, f := range funcs {
+		f(sys)
+	}
+}
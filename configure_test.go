@@ -0,0 +1,84 @@
+package twodeeparticles
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestParticleSystem_Configure(t *testing.T) {
+	is := is.New(t)
+
+	var errs []error
+
+	sys := NewSystem()
+	sys.MaxParticles = 10
+	sys.Debug = true
+	sys.DebugFunc = func(err error) {
+		errs = append(errs, err)
+	}
+	sys.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+		return Vector{1.0, 0.0}
+	}
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return InfiniteLifetime
+	}
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	sys.UpdateFunc = func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		sys.Configure(func(sys *ParticleSystem) {
+			sys.UpdateFunc = nil
+			sys.VelocityOverLifetime = func(p *Particle, t NormalizedDuration, delta time.Duration) Vector {
+				return Vector{0.0, 2.0}
+			}
+		})
+	}
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.Equal(p.Position(), Vector{1.0, 0.0})
+	}, now)
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.Equal(p.Position(), Vector{1.0, 2.0})
+	}, now)
+
+	is.Equal(len(errs), 0)
+}
+
+func TestParticleSystem_Configure_Concurrent(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 10
+
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			sys.Configure(func(sys *ParticleSystem) {
+				sys.MaxParticles++
+			})
+		}()
+	}
+
+	wg.Wait()
+
+	sys.Update(time.Now())
+
+	is.Equal(sys.MaxParticles, 20)
+}
@@ -0,0 +1,64 @@
+package twodeeparticles
+
+import "time"
+
+// A Curve is a function over normalized time, defined by a number of keys. Values between keys are interpolated.
+// Curves are plain data, so they can easily be authored and serialized by tools.
+type Curve struct {
+	// Keys are the keys of the curve, sorted by T.
+	Keys []CurveKey
+
+	// Smooth eases values in and out of each key, instead of interpolating linearly between keys.
+	Smooth bool
+}
+
+// A CurveKey is a key of a Curve.
+type CurveKey struct {
+	// T is the normalized time of the key.
+	T NormalizedDuration
+
+	// Value is the value of the curve at T.
+	Value float64
+}
+
+// Value returns the value of c at t. Before the first key, the value of the first key is returned. After the last key,
+// the value of the last key is returned. If c has no keys, it returns 0.0.
+func (c Curve) Value(t NormalizedDuration) float64 {
+	if len(c.Keys) == 0 {
+		return 0.0
+	}
+
+	if t <= c.Keys[0].T {
+		return c.Keys[0].Value
+	}
+
+	for idx := 1; idx < len(c.Keys); idx++ {
+		k1 := c.Keys[idx-1]
+		k2 := c.Keys[idx]
+
+		if t > k2.T {
+			continue
+		}
+
+		if k2.T <= k1.T {
+			return k2.Value
+		}
+
+		f := float64((t - k1.T) / (k2.T - k1.T))
+		if c.Smooth {
+			f = f * f * (3.0 - 2.0*f)
+		}
+
+		return k1.Value + (k2.Value-k1.Value)*f
+	}
+
+	return c.Keys[len(c.Keys)-1].Value
+}
+
+// OverLifetime returns a function that returns the value of c over a particle's lifetime. It can be used as
+// ParticleSystem.SpeedOverLifetime, for example.
+func (c Curve) OverLifetime() ParticleValueOverNormalizedTimeFunc {
+	return func(p *Particle, t NormalizedDuration, delta time.Duration) float64 {
+		return c.Value(t)
+	}
+}
@@ -0,0 +1,33 @@
+package twodeeparticles
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestCurve_Value(t *testing.T) {
+	is := is.New(t)
+
+	c := Curve{
+		Keys: []CurveKey{
+			{T: 0.2, Value: 10},
+			{T: 0.6, Value: 20},
+			{T: 0.6, Value: 30},
+			{T: 1.0, Value: 0},
+		},
+	}
+
+	is.Equal(Curve{}.Value(0.5), 0.0)
+	is.Equal(c.Value(0.0), 10.0)
+	is.Equal(c.Value(0.2), 10.0)
+	is.True(approxEqual(c.Value(0.4), 15.0))
+	is.Equal(c.Value(0.6), 20.0)
+	is.True(approxEqual(c.Value(0.8), 15.0))
+	is.Equal(c.Value(1.0), 0.0)
+
+	c.Smooth = true
+	is.True(approxEqual(c.Value(0.4), 15.0))
+	is.True(c.Value(0.3) < 12.5)
+	is.True(c.Value(0.5) > 17.5)
+}
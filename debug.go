@@ -0,0 +1,84 @@
+package twodeeparticles
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var errConfigChangedDuringUpdate = errors.New("configuration changed during update")
+
+// A configFingerprint records the parts of a system's configuration that must not be changed during Update.
+type configFingerprint struct {
+	maxParticles int
+	funcNames    []string
+	funcs        []uintptr
+}
+
+// fingerprint returns the current fingerprint of sys's configuration. Function fields are recorded by their code
+// pointers (see reflect.Value.Pointer), so that assigning a different function, or nil, is detected. Assigning
+// a new closure of the same function literal is not detected.
+func (sys *ParticleSystem) fingerprint() configFingerprint {
+	fp := configFingerprint{
+		maxParticles: sys.MaxParticles,
+	}
+
+	v := reflect.ValueOf(sys).Elem()
+	t := v.Type()
+
+	for idx := 0; idx < t.NumField(); idx++ {
+		f := t.Field(idx)
+		if !f.IsExported() || f.Type.Kind() != reflect.Func {
+			continue
+		}
+
+		fp.funcNames = append(fp.funcNames, f.Name)
+		fp.funcs = append(fp.funcs, v.Field(idx).Pointer())
+	}
+
+	return fp
+}
+
+// changed returns the name of the first field that differs between fp and fp2, or "" if there are no differences.
+func (fp configFingerprint) changed(fp2 configFingerprint) string {
+	if fp.maxParticles != fp2.maxParticles {
+		return "MaxParticles"
+	}
+
+	for idx, f := range fp.funcs {
+		if f != fp2.funcs[idx] {
+			return fp.funcNames[idx]
+		}
+	}
+
+	return ""
+}
+
+// checkFingerprint reports an error if sys's configuration differs from fp (see Debug.)
+func (sys *ParticleSystem) checkFingerprint(fp configFingerprint) {
+	name := fp.changed(sys.fingerprint())
+	if name == "" {
+		return
+	}
+
+	err := fmt.Errorf("%w: %s", errConfigChangedDuringUpdate, name)
+
+	if sys.DebugFunc != nil {
+		sys.DebugFunc(err)
+		return
+	}
+
+	panic(err)
+}
+
+// finishUpdate checks that sys's configuration has not been changed since prepareUpdate, if Debug was enabled.
+func (sys *ParticleSystem) finishUpdate() {
+	if sys.debugFingerprint == nil {
+		return
+	}
+
+	fp := *sys.debugFingerprint
+	sys.debugFingerprint = nil
+
+	sys.checkFingerprint(fp)
+}
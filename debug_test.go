@@ -0,0 +1,109 @@
+package twodeeparticles
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestParticleSystem_Update_Debug(t *testing.T) {
+	is := is.New(t)
+
+	var errs []error
+
+	sys := NewSystem()
+	sys.MaxParticles = 10
+	sys.Debug = true
+	sys.DebugFunc = func(err error) {
+		errs = append(errs, err)
+	}
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	is.Equal(len(errs), 0)
+
+	sys.UpdateFunc = func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		sys.ColorOverLifetime = nil
+	}
+
+	now = now.Add(100 * time.Millisecond)
+	sys.Update(now)
+
+	is.Equal(len(errs), 0)
+
+	sys.UpdateFunc = func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		sys.UpdateFunc = func(p *Particle, t NormalizedDuration, delta time.Duration) {}
+	}
+
+	now = now.Add(100 * time.Millisecond)
+	sys.Update(now)
+
+	is.Equal(len(errs), 1)
+	is.True(errors.Is(errs[0], errConfigChangedDuringUpdate))
+	is.Equal(errs[0].Error(), "configuration changed during update: UpdateFunc")
+
+	sys.UpdateFunc = func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		sys.MaxParticles = 20
+	}
+
+	now = now.Add(100 * time.Millisecond)
+	sys.Update(now)
+
+	is.Equal(len(errs), 2)
+	is.Equal(errs[1].Error(), "configuration changed during update: MaxParticles")
+}
+
+func TestParticleSystem_Update_DebugPanic(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 10
+	sys.Debug = true
+	sys.Spawn(1)
+	sys.UpdateFunc = func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		sys.ScaleOverLifetime = func(p *Particle, t NormalizedDuration, delta time.Duration) Vector {
+			return OneVector
+		}
+	}
+
+	defer func() {
+		err, ok := recover().(error)
+		is.True(ok)
+		is.True(errors.Is(err, errConfigChangedDuringUpdate))
+	}()
+
+	sys.Update(time.Now())
+}
+
+func TestParticleSystem_UpdateBudget_Debug(t *testing.T) {
+	is := is.New(t)
+
+	var errs []error
+
+	sys := NewSystem()
+	sys.MaxParticles = 10
+	sys.Debug = true
+	sys.DebugFunc = func(err error) {
+		errs = append(errs, err)
+	}
+	sys.Spawn(1)
+
+	now := time.Now()
+	is.True(sys.UpdateBudget(now, time.Hour))
+
+	is.Equal(len(errs), 0)
+
+	sys.UpdateFunc = func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		sys.MaxParticles = 20
+	}
+
+	now = now.Add(100 * time.Millisecond)
+	is.True(sys.UpdateBudget(now, time.Hour))
+
+	is.Equal(len(errs), 1)
+	is.Equal(errs[0].Error(), "configuration changed during update: MaxParticles")
+}
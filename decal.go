@@ -0,0 +1,133 @@
+package twodeeparticles
+
+import (
+	"image/color"
+	"time"
+)
+
+// A Decal is a static snapshot of a particle that persists after the particle has died, for example a blood splat or
+// a scorch mark. Decals are not simulated, so they are much cheaper than keeping particles alive.
+type Decal struct {
+	// Position is the position of the decal, relative to the origin of the system that the particle was a part of.
+	Position Vector
+
+	// Angle is the rotation angle of the decal, in radians.
+	Angle float64
+
+	// Scale is the scale (size multiplier) of the decal.
+	Scale Vector
+
+	// Color is the color of the decal.
+	Color color.Color
+
+	// Data is the arbitrary data of the particle (see Particle.Data), for example the sprite to draw.
+	Data any
+
+	// BakeTime is the time the decal has been created at.
+	BakeTime time.Time
+}
+
+// DecalVisitFunc is a function that is called for a decal when iterating over decals. c is the decal's color, faded
+// according to Decals.FadeDuration.
+type DecalVisitFunc func(d *Decal, c color.Color)
+
+// Decals is a list of decals. Particles can be baked into decals at any time using Bake. To bake particles when they
+// die, Bake can be used as ParticleSystem.DeathFunc.
+//
+// The zero value is ready to use, and keeps decals forever.
+type Decals struct {
+	// MaxDecals is the maximum number of decals. When the maximum has been reached, the oldest decals are removed
+	// to make room for new ones. If MaxDecals is 0, the number of decals is not limited.
+	MaxDecals int
+
+	// Lifetime is the time after which decals are removed. If Lifetime is 0, decals are kept forever.
+	Lifetime time.Duration
+
+	// FadeDuration is the duration at the end of Lifetime during which the colors of decals fade out.
+	FadeDuration time.Duration
+
+	decals []Decal
+}
+
+// Bake creates a decal from p's current state. The decal's bake time is the time of p's most recent update.
+func (d *Decals) Bake(p *Particle) {
+	d.Add(Decal{
+		Position: p.position,
+		Angle:    p.angle,
+		Scale:    p.scale,
+		Color:    p.color,
+		Data:     p.data,
+		BakeTime: p.system.clock.wall(p.lastUpdateTime),
+	})
+}
+
+// Add adds dec to the list of decals.
+func (d *Decals) Add(dec Decal) {
+	if d.MaxDecals > 0 && len(d.decals) >= d.MaxDecals {
+		num := len(d.decals) - d.MaxDecals + 1
+		d.removeOldest(num)
+	}
+
+	d.decals = append(d.decals, dec)
+}
+
+// Len returns the number of decals.
+func (d *Decals) Len() int {
+	return len(d.decals)
+}
+
+// Clear removes all decals.
+func (d *Decals) Clear() {
+	d.removeOldest(len(d.decals))
+}
+
+// ForEachDecal calls fun for each decal, in the order they have been added. Decals that have exceeded Lifetime
+// are removed first. now should usually be time.Now().
+func (d *Decals) ForEachDecal(fun DecalVisitFunc, now time.Time) {
+	d.removeExpired(now)
+
+	for idx := range d.decals {
+		dec := &d.decals[idx]
+		fun(dec, d.color(dec, now))
+	}
+}
+
+func (d *Decals) color(dec *Decal, now time.Time) color.Color {
+	if d.Lifetime <= 0 || d.FadeDuration <= 0 {
+		return dec.Color
+	}
+
+	left := d.Lifetime - now.Sub(dec.BakeTime)
+	if left >= d.FadeDuration {
+		return dec.Color
+	}
+
+	return fadeColor(dec.Color, left.Seconds()/d.FadeDuration.Seconds())
+}
+
+func (d *Decals) removeExpired(now time.Time) {
+	if d.Lifetime <= 0 {
+		return
+	}
+
+	num := 0
+	for num < len(d.decals) && now.Sub(d.decals[num].BakeTime) >= d.Lifetime {
+		num++
+	}
+
+	d.removeOldest(num)
+}
+
+func (d *Decals) removeOldest(num int) {
+	if num <= 0 {
+		return
+	}
+
+	n := copy(d.decals, d.decals[num:])
+
+	for idx := n; idx < len(d.decals); idx++ {
+		d.decals[idx] = Decal{}
+	}
+
+	d.decals = d.decals[:n]
+}
@@ -0,0 +1,113 @@
+package twodeeparticles
+
+import (
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestDecals_Bake(t *testing.T) {
+	is := is.New(t)
+
+	decals := Decals{}
+
+	sys := NewSystem()
+	sys.MaxParticles = 1
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return time.Second
+	}
+	sys.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+		return Vector{10, 0}
+	}
+	sys.ColorOverLifetime = func(p *Particle, t NormalizedDuration, delta time.Duration) color.Color {
+		return color.RGBA{0xff, 0x00, 0x00, 0xff}
+	}
+	sys.DataOverLifetime = func(old any, t NormalizedDuration, delta time.Duration) any {
+		return "splat"
+	}
+	sys.DeathFunc = decals.Bake
+
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(500 * time.Millisecond)
+	sys.Update(now)
+
+	is.Equal(decals.Len(), 0)
+
+	now = now.Add(time.Second)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 0)
+	is.Equal(decals.Len(), 1)
+
+	decals.ForEachDecal(func(d *Decal, c color.Color) {
+		// the particle's most recent update was before it died
+		is.True(approxEqualVector(d.Position, Vector{5, 0}))
+		is.Equal(d.BakeTime, now.Add(-time.Second))
+		is.Equal(d.Data, "splat")
+		is.Equal(c, color.Color(color.RGBA{0xff, 0x00, 0x00, 0xff}))
+	}, now)
+}
+
+func TestDecals_Add_MaxDecals(t *testing.T) {
+	is := is.New(t)
+
+	decals := Decals{MaxDecals: 2}
+
+	for i := 0; i < 5; i++ {
+		decals.Add(Decal{Position: Vector{float64(i), 0}})
+	}
+
+	is.Equal(decals.Len(), 2)
+
+	var positions []Vector
+
+	decals.ForEachDecal(func(d *Decal, c color.Color) {
+		positions = append(positions, d.Position)
+	}, time.Now())
+
+	is.Equal(positions, []Vector{{3, 0}, {4, 0}})
+
+	decals.Clear()
+	is.Equal(decals.Len(), 0)
+}
+
+func TestDecals_ForEachDecal_Lifetime(t *testing.T) {
+	is := is.New(t)
+
+	decals := Decals{Lifetime: 2 * time.Second, FadeDuration: time.Second}
+
+	now := time.Now()
+
+	decals.Add(Decal{Color: color.White, BakeTime: now})
+	decals.Add(Decal{Color: color.White, BakeTime: now.Add(time.Second)})
+
+	var colors []color.Color
+
+	visit := func(d *Decal, c color.Color) {
+		colors = append(colors, c)
+	}
+
+	decals.ForEachDecal(visit, now.Add(500*time.Millisecond))
+	is.Equal(colors, []color.Color{color.White, color.White})
+
+	colors = nil
+
+	decals.ForEachDecal(visit, now.Add(1500*time.Millisecond))
+	is.Equal(len(colors), 2)
+
+	_, _, _, a := colors[0].RGBA()
+	is.Equal(a, uint32(0x7fff))
+	is.Equal(colors[1], color.Color(color.White))
+
+	colors = nil
+
+	decals.ForEachDecal(visit, now.Add(2*time.Second))
+	is.Equal(decals.Len(), 1)
+	is.Equal(len(colors), 1)
+}
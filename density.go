@@ -0,0 +1,51 @@
+package twodeeparticles
+
+import (
+	"image/color"
+	"time"
+)
+
+// computeDensities computes the local density of particles around each particle, according to DensityRadius.
+func (sys *ParticleSystem) computeDensities() {
+	radius := sys.DensityRadius
+	if radius <= 0 {
+		return
+	}
+
+	sys.buildGrid(radius)
+
+	for _, p := range sys.particles {
+		if !p.isAlive {
+			continue
+		}
+
+		density := 0.0
+
+		sys.forEachNear(p.position, radius, func(other *Particle, diff Vector, dist float64) {
+			density += 1.0 - dist/radius
+		})
+
+		p.density = density
+	}
+}
+
+// OpacityFromDensity returns a function that can be used as ParticleSystem.ColorOverLifetime. It modulates
+// the opacity of the colors returned by fun according to particles' densities (see Particle.Density), so that dense
+// regions of an effect look thicker. Particles with a density of full or higher are fully opaque. If fun is nil,
+// color.White is modulated.
+//
+// ParticleSystem.DensityRadius must be set for densities to be computed.
+func OpacityFromDensity(fun ParticleColorOverNormalizedTimeFunc, full float64) ParticleColorOverNormalizedTimeFunc {
+	return func(p *Particle, t NormalizedDuration, delta time.Duration) color.Color {
+		c := white
+		if fun != nil {
+			c = fun(p, t, delta)
+		}
+
+		if full <= 0 {
+			return c
+		}
+
+		return fadeColor(c, p.density/full)
+	}
+}
@@ -0,0 +1,49 @@
+package twodeeparticles
+
+import (
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestParticle_Density(t *testing.T) {
+	is := is.New(t)
+
+	positions := []Vector{{0, 0}, {2, 0}, {20, 0}}
+
+	sys := NewSystem()
+	sys.MaxParticles = len(positions)
+	sys.DensityRadius = 4.0
+	sys.ColorOverLifetime = OpacityFromDensity(nil, 1.5)
+
+	idx := 0
+	sys.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) Vector {
+		pos := positions[idx]
+		idx++
+
+		return pos
+	}
+
+	sys.Spawn(len(positions))
+
+	now := time.Now()
+	sys.Update(now)
+
+	var densities []float64
+
+	var colors []color.Color
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		densities = append(densities, p.Density())
+		colors = append(colors, p.Color())
+	}, now)
+
+	is.Equal(densities, []float64{1.5, 1.5, 1.0})
+
+	is.Equal(colors[0], color.RGBA64{0xffff, 0xffff, 0xffff, 0xffff})
+
+	_, _, _, a := colors[2].RGBA()
+	is.Equal(a, uint32(0xffff*2/3))
+}
@@ -0,0 +1,223 @@
+package twodeeparticles
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// A Dissolve is a preset that disintegrates a sprite into particles. Over the course of a duration, each opaque
+// pixel of the sprite's image is emitted as a particle of the pixel's color, in the order specified by a DissolveOrder.
+// Particles drift away and fade out over their lifetime.
+//
+// To draw the part of the sprite that has not yet dissolved, use IsDissolved.
+//
+// The Data of a dissolve particle is a *DissolvedPixel.
+type Dissolve struct {
+	// Velocity is the initial velocity of particles, in arbitrary units per second.
+	Velocity Vector
+
+	// VelocityVariance is the maximum random deviation from Velocity, in arbitrary units per second.
+	VelocityVariance float64
+
+	// Gravity is the acceleration of particles, in arbitrary units per second squared.
+	Gravity Vector
+
+	// Lifetime is the lifetime of particles.
+	Lifetime time.Duration
+
+	img       image.Image
+	offset    Vector
+	duration  time.Duration
+	keys      []float64
+	order     []int
+	next      int
+	requested int
+	progress  float64
+	sys       *ParticleSystem
+	rand      *rand.Rand
+}
+
+// DissolveOrder specifies the order in which the pixels of a sprite dissolve.
+type DissolveOrder int
+
+const (
+	// DissolveTopToBottom dissolves the sprite row by row, starting at the top.
+	DissolveTopToBottom DissolveOrder = iota
+
+	// DissolveRandom dissolves pixels in random order.
+	DissolveRandom
+)
+
+// A DissolvedPixel is the data attached to a dissolve particle.
+type DissolvedPixel struct {
+	// X and Y are the coordinates of the pixel in the sprite's image.
+	X, Y int
+
+	// Color is the color of the pixel.
+	Color color.Color
+}
+
+// NewDissolve returns a new dissolve effect for the opaque area of img, lasting for duration. A pixel is considered
+// opaque if its alpha is at least threshold, in the range [0.0,1.0]. The positions of particles are measured in
+// pixels, relative to img's top left corner, plus offset. For example, if the image is drawn centered at the system's
+// origin, offset should be minus half its size.
+//
+// The system's MaxParticles is set to the number of opaque pixels. The effect starts on the first Update.
+func NewDissolve(img image.Image, threshold float64, offset Vector, duration time.Duration, order DissolveOrder,
+	rand *rand.Rand,
+) *Dissolve {
+	d := Dissolve{
+		Velocity:         Vector{0.0, -30.0},
+		VelocityVariance: 20.0,
+		Lifetime:         1 * time.Second,
+		img:              img,
+		offset:           offset,
+		duration:         duration,
+		sys:              NewSystem(),
+		rand:             rand,
+	}
+
+	bounds := img.Bounds()
+	opaque := opaqueFunc(img, threshold)
+
+	d.keys = make([]float64, bounds.Dx()*bounds.Dy())
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			idx := d.index(x, y)
+
+			if !opaque(x, y) {
+				d.keys[idx] = math.Inf(-1)
+				continue
+			}
+
+			switch order {
+			case DissolveTopToBottom:
+				d.keys[idx] = (float64(y-bounds.Min.Y) + rand.Float64()) / float64(bounds.Dy())
+			case DissolveRandom:
+				d.keys[idx] = rand.Float64()
+			}
+
+			d.order = append(d.order, idx)
+		}
+	}
+
+	sort.Slice(d.order, func(i int, j int) bool {
+		return d.keys[d.order[i]] < d.keys[d.order[j]]
+	})
+
+	d.sys.MaxParticles = len(d.order)
+	d.sys.EmissionRateOverTime = d.emit
+	d.sys.EmissionPositionOverTime = d.emissionPosition
+	d.sys.InitialVelocityOverTime = d.initialVelocity
+	d.sys.LifetimeOverTime = d.lifetime
+	d.sys.UpdateFunc = d.update
+	d.sys.VelocityOverLifetime = d.velocity
+	d.sys.ColorOverLifetime = d.color
+
+	return &d
+}
+
+// System returns the particle system that simulates d.
+func (d *Dissolve) System() *ParticleSystem {
+	return d.sys
+}
+
+// Progress returns the fraction of the sprite that has dissolved, in the range [0.0,1.0].
+func (d *Dissolve) Progress() float64 {
+	return d.progress
+}
+
+// IsDissolved returns whether the pixel of the sprite's image at (x,y) has dissolved. Pixels that are not opaque
+// are always considered dissolved.
+func (d *Dissolve) IsDissolved(x int, y int) bool {
+	if !(image.Point{x, y}).In(d.img.Bounds()) {
+		return true
+	}
+
+	return d.keys[d.index(x, y)] <= d.progress
+}
+
+// Done returns whether the sprite has dissolved completely, and all particles have died.
+func (d *Dissolve) Done() bool {
+	return d.next >= len(d.order) && d.sys.NumParticles() == 0
+}
+
+func (d *Dissolve) index(x int, y int) int {
+	bounds := d.img.Bounds()
+	return (y-bounds.Min.Y)*bounds.Dx() + x - bounds.Min.X
+}
+
+// emit advances the dissolve, and spawns the pixels that are due as a burst, so that their number is exact instead
+// of being subject to rounding. It is used as the system's EmissionRateOverTime, and always returns 0.
+func (d *Dissolve) emit(dur time.Duration, delta time.Duration) float64 {
+	if d.duration <= 0 {
+		d.progress = 1.0
+	} else {
+		d.progress = math.Min(dur.Seconds()/d.duration.Seconds(), 1.0)
+	}
+
+	target := sort.Search(len(d.order), func(i int) bool {
+		return d.keys[d.order[i]] > d.progress
+	})
+
+	if num := target - d.requested; num > 0 {
+		d.sys.SpawnBurst(Burst{Count: num})
+	}
+
+	d.requested = target
+
+	return 0.0
+}
+
+func (d *Dissolve) emissionPosition(dur time.Duration, delta time.Duration) Vector {
+	if d.next >= len(d.order) {
+		return d.offset
+	}
+
+	idx := d.order[d.next]
+	d.next++
+
+	w := d.img.Bounds().Dx()
+
+	return Vector{float64(idx%w) + 0.5, float64(idx/w) + 0.5}.Add(d.offset)
+}
+
+func (d *Dissolve) initialVelocity(dur time.Duration, delta time.Duration, spawnPos Vector) Vector {
+	v := VectorFromAngle(d.rand.Float64() * 2.0 * math.Pi).Multiply(d.rand.Float64() * d.VelocityVariance)
+	return d.Velocity.Add(v)
+}
+
+func (d *Dissolve) lifetime(dur time.Duration, delta time.Duration) time.Duration {
+	return d.Lifetime
+}
+
+func (d *Dissolve) update(p *Particle, t NormalizedDuration, delta time.Duration) {
+	if p.data != nil {
+		return
+	}
+
+	bounds := d.img.Bounds()
+	pos := p.SpawnPosition().Add(d.offset.Multiply(-1.0))
+	x := bounds.Min.X + int(math.Floor(pos.X))
+	y := bounds.Min.Y + int(math.Floor(pos.Y))
+
+	p.data = &DissolvedPixel{
+		X:     x,
+		Y:     y,
+		Color: d.img.At(x, y),
+	}
+}
+
+func (d *Dissolve) velocity(p *Particle, t NormalizedDuration, delta time.Duration) Vector {
+	return p.Velocity().Add(d.Gravity.Multiply(delta.Seconds()))
+}
+
+func (d *Dissolve) color(p *Particle, t NormalizedDuration, delta time.Duration) color.Color {
+	px := p.Data().(*DissolvedPixel) //nolint:forcetypeassert // we know this is a *DissolvedPixel
+	return fadeColor(px.Color, 1.0-float64(t))
+}
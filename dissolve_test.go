@@ -0,0 +1,109 @@
+package twodeeparticles
+
+import (
+	"image"
+	"image/color"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestDissolve_TopToBottom(t *testing.T) {
+	is := is.New(t)
+
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			img.Set(x, y, color.RGBA{uint8(y), 0x00, 0x00, 0xff})
+		}
+	}
+
+	d := NewDissolve(img, 0.5, ZeroVector, 4*time.Second, DissolveTopToBottom, rand.New(rand.NewSource(1))) //nolint:gosec // no crypto
+	d.Lifetime = 10 * time.Second
+
+	is.Equal(d.System().MaxParticles, 16)
+
+	now := time.Now()
+	d.System().Update(now)
+
+	is.Equal(d.System().NumParticles(), 0)
+	is.True(!d.IsDissolved(0, 0))
+
+	now = now.Add(1 * time.Second)
+	d.System().Update(now)
+
+	is.True(d.IsDissolved(0, 0))
+	is.True(!d.IsDissolved(0, 1))
+	is.True(!d.IsDissolved(3, 3))
+
+	d.System().ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		px := p.Data().(*DissolvedPixel) //nolint:forcetypeassert // we know this is a *DissolvedPixel
+		is.True(px.Y <= 1)
+		is.Equal(px.Color, img.At(px.X, px.Y))
+		is.True(d.IsDissolved(px.X, px.Y))
+	}, now)
+
+	for i := 0; i < 3; i++ {
+		now = now.Add(1 * time.Second)
+		d.System().Update(now)
+	}
+
+	is.Equal(d.Progress(), 1.0)
+	is.Equal(d.System().NumParticles(), 16)
+	is.True(d.IsDissolved(3, 3))
+	is.True(!d.Done())
+}
+
+func TestDissolve_Transparent(t *testing.T) {
+	is := is.New(t)
+
+	img := image.NewAlpha(image.Rect(0, 0, 4, 4))
+	img.SetAlpha(1, 1, color.Alpha{0xff})
+	img.SetAlpha(2, 2, color.Alpha{0xff})
+
+	d := NewDissolve(img, 0.5, Vector{-2, -2}, time.Second, DissolveRandom, rand.New(rand.NewSource(1))) //nolint:gosec // no crypto
+	d.Lifetime = 500 * time.Millisecond
+
+	is.Equal(d.System().MaxParticles, 2)
+	is.True(d.IsDissolved(0, 0))
+
+	now := time.Now()
+	d.System().Update(now)
+
+	now = now.Add(1 * time.Second)
+	d.System().Update(now)
+
+	is.Equal(d.System().NumParticles(), 2)
+
+	now = now.Add(1 * time.Second)
+	d.System().Update(now)
+
+	is.True(d.Done())
+}
+
+func TestDissolve_OddDeltas(t *testing.T) {
+	is := is.New(t)
+
+	img := image.NewAlpha(image.Rect(0, 0, 3, 3))
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 3; x++ {
+			img.SetAlpha(x, y, color.Alpha{0xff})
+		}
+	}
+
+	d := NewDissolve(img, 0.5, ZeroVector, time.Second, DissolveRandom, rand.New(rand.NewSource(1))) //nolint:gosec // no crypto
+	d.Lifetime = 100 * time.Millisecond
+
+	now := time.Now()
+	d.System().Update(now)
+
+	for i := 0; i < 2000; i++ {
+		now = now.Add(2666666 * time.Nanosecond)
+		d.System().Update(now)
+	}
+
+	is.Equal(d.Progress(), 1.0)
+	is.True(d.Done())
+}
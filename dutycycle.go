@@ -0,0 +1,73 @@
+package twodeeparticles
+
+import (
+	"math"
+	"time"
+)
+
+// A DutyCycle switches periodically between on and off states. This can be used for blinking effects, or for
+// intermittent emission such as sprinklers or beacons.
+//
+// For example, a cycle that is on for 1 second and then off for 3 seconds has a Period of 4 seconds and a Duty of 0.25.
+type DutyCycle struct {
+	// Period is the duration of a full on/off cycle.
+	Period time.Duration
+
+	// Duty is the fraction of Period that the cycle is on, in the range [0.0,1.0]. Each cycle starts with the on state.
+	Duty float64
+
+	// Phase shifts the cycle forward by a duration. For example, if Phase is half of Period, the cycle starts halfway
+	// through.
+	Phase time.Duration
+
+	// RandomPhase shifts the cycle by a random amount for each particle, so that particles do not blink in unison.
+	// It is only used by VisibilityOverLifetime.
+	RandomPhase bool
+}
+
+// On returns whether the cycle is on after duration d has passed.
+func (c DutyCycle) On(d time.Duration) bool {
+	return c.on(d.Seconds(), 0.0)
+}
+
+// Rate returns a function that can be used as ParticleSystem.EmissionRateOverTime. It returns the emission rate
+// returned by fun while the cycle is on, and 0.0 while it is off. This allows to combine intermittent emission with
+// an emission rate that changes over time.
+func (c DutyCycle) Rate(fun ValueOverTimeFunc) ValueOverTimeFunc {
+	return func(d time.Duration, delta time.Duration) float64 {
+		if !c.On(d) {
+			return 0.0
+		}
+
+		return fun(d, delta)
+	}
+}
+
+// VisibilityOverLifetime returns a function that can be used as ParticleSystem.VisibilityOverLifetime. Particles are
+// visible while the cycle is on. The cycle is based on particles' ages, so that it also works for particles with
+// an infinite lifetime.
+func (c DutyCycle) VisibilityOverLifetime() ParticleBoolOverNormalizedTimeFunc {
+	return func(p *Particle, t NormalizedDuration, delta time.Duration) bool {
+		phase := 0.0
+		if c.RandomPhase {
+			phase = p.Random(randomDutyCyclePhase)
+		}
+
+		return c.on(p.Age().Seconds(), phase)
+	}
+}
+
+// on returns whether the cycle is on after sec seconds have passed, with the cycle shifted by phase, in the range
+// [0.0,1.0).
+func (c DutyCycle) on(sec float64, phase float64) bool {
+	if c.Period <= 0 {
+		return c.Duty > 0
+	}
+
+	f := math.Mod(sec/c.Period.Seconds()+c.Phase.Seconds()/c.Period.Seconds()+phase, 1.0)
+	if f < 0.0 {
+		f += 1.0
+	}
+
+	return f < c.Duty
+}
@@ -0,0 +1,91 @@
+package twodeeparticles
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestDutyCycle_On(t *testing.T) {
+	is := is.New(t)
+
+	c := DutyCycle{Period: 1 * time.Second, Duty: 0.25}
+
+	is.True(c.On(0))
+	is.True(c.On(200 * time.Millisecond))
+	is.True(!c.On(300 * time.Millisecond))
+	is.True(!c.On(900 * time.Millisecond))
+	is.True(c.On(1100 * time.Millisecond))
+
+	is.True(!DutyCycle{}.On(1 * time.Second))
+	is.True(DutyCycle{Duty: 1.0}.On(1 * time.Second))
+}
+
+func TestDutyCycle_VisibilityOverLifetime(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 1
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 10 * time.Second
+	}
+	sys.VisibilityOverLifetime = DutyCycle{Period: 1 * time.Second, Duty: 0.5}.VisibilityOverLifetime()
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	snap := sys.SnapshotForRender(now)
+	is.Equal(len(snap.Particles), 1)
+	snap.Release()
+
+	now = now.Add(750 * time.Millisecond)
+	sys.Update(now)
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.True(!p.Visible())
+	}, now)
+
+	snap = sys.SnapshotForRender(now)
+	is.Equal(len(snap.Particles), 0)
+	snap.Release()
+}
+
+func TestDutyCycle_On_Phase(t *testing.T) {
+	is := is.New(t)
+
+	c := DutyCycle{Period: 4 * time.Second, Duty: 0.25, Phase: 3 * time.Second}
+
+	is.True(!c.On(0))
+	is.True(c.On(1500 * time.Millisecond))
+	is.True(!c.On(2500 * time.Millisecond))
+
+	c.Phase = -1 * time.Second
+
+	is.True(!c.On(0))
+	is.True(c.On(1500 * time.Millisecond))
+}
+
+func TestDutyCycle_Rate(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 100
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return InfiniteLifetime
+	}
+	sys.EmissionRateOverTime = DutyCycle{Period: 2 * time.Second, Duty: 0.5}.Rate(func(d time.Duration, delta time.Duration) float64 {
+		return 10.0
+	})
+
+	now := time.Now()
+	sys.Update(now)
+
+	for i := 0; i < 8; i++ {
+		now = now.Add(500 * time.Millisecond)
+		sys.Update(now)
+	}
+
+	is.Equal(sys.NumParticles(), 20)
+}
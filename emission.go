@@ -0,0 +1,31 @@
+package twodeeparticles
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RandomWalkPositionOverTime returns a function that can be used as ParticleSystem.EmissionPositionOverTime.
+// The positions returned form a continuous random walk: Each position is offset from the previous position
+// by step, in a random direction. The walk begins at start. If maxDistance is greater than 0, the walk stays
+// within maxDistance around start by stepping back towards start whenever it would leave that area.
+//
+// The returned function keeps track of the previous position, so it should not be shared between systems.
+func RandomWalkPositionOverTime(start Vector, step float64, maxDistance float64, rand *rand.Rand) VectorOverTimeFunc {
+	pos := start
+
+	return func(d time.Duration, delta time.Duration) Vector {
+		next := pos.Add(VectorFromAngle(rand.Float64() * 2.0 * math.Pi).Multiply(step))
+
+		if maxDistance > 0 && next.Add(start.Multiply(-1.0)).Magnitude() > maxDistance {
+			if dir, ok := start.Add(pos.Multiply(-1.0)).TryNormalize(); ok {
+				next = pos.Add(dir.Multiply(step))
+			}
+		}
+
+		pos = next
+
+		return pos
+	}
+}
@@ -0,0 +1,26 @@
+package twodeeparticles
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestRandomWalkPositionOverTime(t *testing.T) {
+	is := is.New(t)
+
+	start := Vector{17, 23}
+	fun := RandomWalkPositionOverTime(start, 2.0, 10.0, rand.New(rand.NewSource(1))) //nolint:gosec // no crypto
+
+	prev := start
+
+	for i := 0; i < 1000; i++ {
+		pos := fun(0, 0)
+
+		is.True(approxEqual(pos.Add(prev.Multiply(-1.0)).Magnitude(), 2.0))
+		is.True(pos.Add(start.Multiply(-1.0)).Magnitude() <= 10.0+1e-9)
+
+		prev = pos
+	}
+}
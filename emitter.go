@@ -0,0 +1,264 @@
+package twodeeparticles
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// An EmitterShape is a shape that particles can be emitted from (see PositionFromShape.) Emitter shapes only determine
+// the positions of particles. They can be combined with an EmissionPattern to determine directions
+// (see VelocityFromPattern.)
+type EmitterShape interface {
+	Shape
+
+	// RandomPoint returns a random point that is uniformly distributed inside of the shape. If edge is set, the point
+	// is uniformly distributed along the shape's edge instead.
+	RandomPoint(rand *rand.Rand, edge bool) Vector
+}
+
+// A Point is a shape that consists of a single position.
+type Point struct {
+	Position Vector
+}
+
+// A Circle is a filled circle.
+type Circle struct {
+	Center Vector
+	Radius float64
+}
+
+// A Ring is a filled area between two concentric circles. Its edge consists of both circles.
+type Ring struct {
+	Center      Vector
+	InnerRadius float64
+	OuterRadius float64
+}
+
+// A Line is a line segment between two points. Since a line has no area, it does not contain any points, and its
+// inside and its edge are the same.
+type Line struct {
+	Start Vector
+	End   Vector
+}
+
+// An Arc is a filled circular sector, that is, a slice of a circle between two angles. Angles are in radians, and
+// the sector extends from StartAngle to EndAngle, which must be greater than StartAngle. Its edge is the curved part
+// of the sector only.
+type Arc struct {
+	Center     Vector
+	Radius     float64
+	StartAngle float64
+	EndAngle   float64
+}
+
+// A Polygon is a filled polygon. Points are the polygon's vertices, in order. The last vertex is connected to
+// the first vertex. The polygon may be concave, and whether a point is inside is determined using the even-odd rule.
+type Polygon struct {
+	Points []Vector
+}
+
+// polygonMaxAttempts is the maximum number of random points that Polygon.RandomPoint tries before falling back to
+// a point on the polygon's edge.
+const polygonMaxAttempts = 100
+
+var (
+	_ EmitterShape = Point{}
+	_ EmitterShape = Circle{}
+	_ EmitterShape = Ring{}
+	_ EmitterShape = Line{}
+	_ EmitterShape = Arc{}
+	_ EmitterShape = Polygon{}
+	_ EmitterShape = Rect{}
+)
+
+// PositionFromShape returns a function that can be used as ParticleSystem.EmissionPositionOverTime. Particles
+// will be emitted at random positions inside of shape, or along its edge if edge is set.
+func PositionFromShape(shape EmitterShape, edge bool, rand *rand.Rand) VectorOverTimeFunc {
+	return func(d time.Duration, delta time.Duration) Vector {
+		return shape.RandomPoint(rand, edge)
+	}
+}
+
+// Contains returns whether v is at p's position.
+func (p Point) Contains(v Vector) bool {
+	return v == p.Position
+}
+
+// RandomPoint returns p's position.
+func (p Point) RandomPoint(rand *rand.Rand, edge bool) Vector {
+	return p.Position
+}
+
+// Contains returns whether v is inside of c.
+func (c Circle) Contains(v Vector) bool {
+	return c.Center.Add(v.Multiply(-1.0)).Magnitude() <= c.Radius
+}
+
+// RandomPoint returns a random point inside of c, or on its edge if edge is set.
+func (c Circle) RandomPoint(rand *rand.Rand, edge bool) Vector {
+	return Ring{Center: c.Center, OuterRadius: c.Radius}.randomPoint(rand, edge, false)
+}
+
+// Contains returns whether v is inside of r.
+func (r Ring) Contains(v Vector) bool {
+	dist := r.Center.Add(v.Multiply(-1.0)).Magnitude()
+	return dist >= r.InnerRadius && dist <= r.OuterRadius
+}
+
+// RandomPoint returns a random point inside of r, or on one of its circles if edge is set.
+func (r Ring) RandomPoint(rand *rand.Rand, edge bool) Vector {
+	return r.randomPoint(rand, edge, true)
+}
+
+func (r Ring) randomPoint(rand *rand.Rand, edge bool, innerEdge bool) Vector {
+	dir := VectorFromAngle(rand.Float64() * 2.0 * math.Pi)
+
+	if edge {
+		radius := r.OuterRadius
+
+		// choose the inner circle proportionally to its circumference
+		if innerEdge && rand.Float64()*(r.InnerRadius+r.OuterRadius) < r.InnerRadius {
+			radius = r.InnerRadius
+		}
+
+		return r.Center.Add(dir.Multiply(radius))
+	}
+
+	inner2 := r.InnerRadius * r.InnerRadius
+	outer2 := r.OuterRadius * r.OuterRadius
+
+	return r.Center.Add(dir.Multiply(math.Sqrt(inner2 + rand.Float64()*(outer2-inner2))))
+}
+
+// Contains always returns false, since l has no area.
+func (l Line) Contains(v Vector) bool {
+	return false
+}
+
+// RandomPoint returns a random point on l.
+func (l Line) RandomPoint(rand *rand.Rand, edge bool) Vector {
+	return l.at(rand.Float64())
+}
+
+// at returns the point at fraction f along l.
+func (l Line) at(f float64) Vector {
+	return l.Start.Multiply(1.0 - f).Add(l.End.Multiply(f))
+}
+
+// Contains returns whether v is inside of a.
+func (a Arc) Contains(v Vector) bool {
+	diff := v.Add(a.Center.Multiply(-1.0))
+	if diff.Magnitude() > a.Radius {
+		return false
+	}
+
+	if diff == ZeroVector {
+		return true
+	}
+
+	angle := floorMod(math.Atan2(diff.Y, diff.X)-a.StartAngle, 2.0*math.Pi)
+
+	return angle <= a.EndAngle-a.StartAngle
+}
+
+// RandomPoint returns a random point inside of a, or on its curved edge if edge is set.
+func (a Arc) RandomPoint(rand *rand.Rand, edge bool) Vector {
+	dir := VectorFromAngle(a.StartAngle + rand.Float64()*(a.EndAngle-a.StartAngle))
+
+	if edge {
+		return a.Center.Add(dir.Multiply(a.Radius))
+	}
+
+	return a.Center.Add(dir.Multiply(a.Radius * math.Sqrt(rand.Float64())))
+}
+
+// Contains returns whether v is inside of p, using the even-odd rule.
+func (p Polygon) Contains(v Vector) bool {
+	inside := false
+
+	for idx := range p.Points {
+		p1 := p.Points[idx]
+		p2 := p.Points[(idx+1)%len(p.Points)]
+
+		if (p1.Y > v.Y) != (p2.Y > v.Y) && v.X < p1.X+(v.Y-p1.Y)*(p2.X-p1.X)/(p2.Y-p1.Y) {
+			inside = !inside
+		}
+	}
+
+	return inside
+}
+
+// RandomPoint returns a random point inside of p, or on its edge if edge is set. Points inside of p are found by
+// rejection sampling. If no point is found after a number of attempts (for example, because p is very thin),
+// a point on p's edge is returned instead. If p has no points, RandomPoint returns ZeroVector.
+func (p Polygon) RandomPoint(rand *rand.Rand, edge bool) Vector {
+	if len(p.Points) == 0 {
+		return ZeroVector
+	}
+
+	if !edge {
+		bounds := p.bounds()
+
+		for i := 0; i < polygonMaxAttempts; i++ {
+			v := Vector{
+				bounds.Min.X + rand.Float64()*bounds.Width(),
+				bounds.Min.Y + rand.Float64()*bounds.Height(),
+			}
+
+			if p.Contains(v) {
+				return v
+			}
+		}
+	}
+
+	return p.randomEdgePoint(rand)
+}
+
+// randomEdgePoint returns a random point on p's edge.
+func (p Polygon) randomEdgePoint(rand *rand.Rand) Vector {
+	perimeter := 0.0
+
+	for idx := range p.Points {
+		perimeter += p.edge(idx).length()
+	}
+
+	d := rand.Float64() * perimeter
+
+	for idx := range p.Points {
+		l := p.edge(idx)
+
+		length := l.length()
+		if d <= length && length > 0 {
+			return l.at(d / length)
+		}
+
+		d -= length
+	}
+
+	return p.Points[0]
+}
+
+// edge returns the edge of p starting at vertex idx.
+func (p Polygon) edge(idx int) Line {
+	return Line{p.Points[idx], p.Points[(idx+1)%len(p.Points)]}
+}
+
+// bounds returns the bounding rectangle of p.
+func (p Polygon) bounds() Rect {
+	r := Rect{p.Points[0], p.Points[0]}
+
+	for _, v := range p.Points[1:] {
+		r.Min.X = math.Min(r.Min.X, v.X)
+		r.Min.Y = math.Min(r.Min.Y, v.Y)
+		r.Max.X = math.Max(r.Max.X, v.X)
+		r.Max.Y = math.Max(r.Max.Y, v.Y)
+	}
+
+	return r
+}
+
+// length returns l's length.
+func (l Line) length() float64 {
+	return l.End.Add(l.Start.Multiply(-1.0)).Magnitude()
+}
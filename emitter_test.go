@@ -0,0 +1,101 @@
+package twodeeparticles
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestEmitterShape_RandomPoint(t *testing.T) {
+	is := is.New(t)
+
+	rand := rand.New(rand.NewSource(1)) //nolint:gosec // no crypto
+
+	shapes := []EmitterShape{
+		Circle{Center: Vector{10, 20}, Radius: 5},
+		Ring{Center: Vector{10, 20}, InnerRadius: 3, OuterRadius: 5},
+		Arc{Center: Vector{10, 20}, Radius: 5, StartAngle: 0.5, EndAngle: 2.0},
+		Polygon{Points: []Vector{{0, 0}, {10, 0}, {10, 10}, {5, 2}, {0, 10}}},
+		Rect{Min: Vector{-5, -5}, Max: Vector{5, 5}},
+	}
+
+	for _, s := range shapes {
+		for i := 0; i < 1000; i++ {
+			is.True(s.Contains(s.RandomPoint(rand, false)))
+		}
+	}
+
+	p := Point{Vector{1, 2}}
+	is.Equal(p.RandomPoint(rand, false), Vector{1, 2})
+	is.True(p.Contains(Vector{1, 2}))
+}
+
+func TestEmitterShape_RandomPoint_Edge(t *testing.T) {
+	is := is.New(t)
+
+	rand := rand.New(rand.NewSource(1)) //nolint:gosec // no crypto
+
+	center := Vector{10, 20}
+
+	inner, outer := 0, 0
+
+	for i := 0; i < 1000; i++ {
+		dist := Circle{Center: center, Radius: 5}.RandomPoint(rand, true).Add(center.Multiply(-1.0)).Magnitude()
+		is.True(approxEqual(dist, 5))
+
+		dist = Ring{Center: center, InnerRadius: 3, OuterRadius: 5}.RandomPoint(rand, true).Add(center.Multiply(-1.0)).Magnitude()
+
+		switch {
+		case approxEqual(dist, 3):
+			inner++
+		case approxEqual(dist, 5):
+			outer++
+		default:
+			is.Fail()
+		}
+
+		v := Arc{Center: center, Radius: 5, StartAngle: 0.5, EndAngle: 2.0}.RandomPoint(rand, true).Add(center.Multiply(-1.0))
+		is.True(approxEqual(v.Magnitude(), 5))
+
+		a := math.Atan2(v.Y, v.X)
+		is.True(a >= 0.5-1e-9 && a <= 2.0+1e-9)
+
+		v = Rect{Min: Vector{-5, -5}, Max: Vector{5, 5}}.RandomPoint(rand, true)
+		is.True(approxEqual(math.Max(math.Abs(v.X), math.Abs(v.Y)), 5))
+
+		v = Line{Start: Vector{0, 0}, End: Vector{10, 5}}.RandomPoint(rand, true)
+		is.True(approxEqual(v.Y, v.X/2))
+		is.True(v.X >= 0 && v.X <= 10)
+	}
+
+	// the outer circle of the ring is chosen more often, proportional to its circumference
+	is.True(outer > inner)
+	is.True(math.Abs(float64(inner)/1000.0-3.0/8.0) < 0.05)
+}
+
+func TestPolygon_Contains(t *testing.T) {
+	is := is.New(t)
+
+	// a "U" shape
+	p := Polygon{Points: []Vector{{0, 0}, {3, 0}, {3, 3}, {2, 3}, {2, 1}, {1, 1}, {1, 3}, {0, 3}}}
+
+	is.True(p.Contains(Vector{0.5, 2}))
+	is.True(p.Contains(Vector{1.5, 0.5}))
+	is.True(!p.Contains(Vector{1.5, 2}))
+	is.True(!p.Contains(Vector{-1, 1}))
+	is.True(!Polygon{}.Contains(ZeroVector))
+	is.Equal(Polygon{}.RandomPoint(nil, false), ZeroVector)
+}
+
+func TestPositionFromShape(t *testing.T) {
+	is := is.New(t)
+
+	fun := PositionFromShape(Circle{Radius: 10}, true, rand.New(rand.NewSource(1))) //nolint:gosec // no crypto
+
+	for i := 0; i < 100; i++ {
+		is.True(approxEqual(fun(time.Duration(i)*time.Second, 0).Magnitude(), 10))
+	}
+}
@@ -0,0 +1,53 @@
+package twodeeparticles
+
+import "time"
+
+// A periodicFunc is a function that is called at fixed intervals of a system's duration.
+type periodicFunc struct {
+	interval time.Duration
+	next     time.Duration
+	fun      func(sys *ParticleSystem)
+}
+
+// Every schedules fun to be called every d, measured in the system's duration. The first call happens d after Every
+// has been called, or d after the system's start if it has not been updated yet. The calls happen during Update, before
+// particles are spawned or updated. If more than d has passed since the previous Update, fun is called once for each
+// interval that has passed, so the number of calls is independent of the frame rate. This can be used for periodic
+// logic such as picking new Targets, or sampling wind. When the system is reset, the schedules restart.
+//
+// If d is 0 or less, Every does nothing.
+func (sys *ParticleSystem) Every(d time.Duration, fun func(sys *ParticleSystem)) {
+	if d <= 0 {
+		return
+	}
+
+	next := d
+	if !sys.startTime.IsZero() {
+		next += sys.duration(sys.lastUpdateTime)
+	}
+
+	sys.periodicFuncs = append(sys.periodicFuncs, &periodicFunc{
+		interval: d,
+		next:     next,
+		fun:      fun,
+	})
+}
+
+// runPeriodicFuncs calls all functions scheduled using Every that are due.
+func (sys *ParticleSystem) runPeriodicFuncs(now time.Time) {
+	d := sys.duration(now)
+
+	for _, f := range sys.periodicFuncs {
+		for d >= f.next {
+			f.next += f.interval
+			f.fun(sys)
+		}
+	}
+}
+
+// resetPeriodicFuncs restarts all schedules.
+func (sys *ParticleSystem) resetPeriodicFuncs() {
+	for _, f := range sys.periodicFuncs {
+		f.next = f.interval
+	}
+}
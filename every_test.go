@@ -0,0 +1,86 @@
+package twodeeparticles
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestParticleSystem_Every(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+
+	calls := 0
+	sys.Every(100*time.Millisecond, func(s *ParticleSystem) {
+		is.Equal(s, sys)
+		calls++
+	})
+
+	now := time.Now()
+	sys.Update(now)
+	is.Equal(calls, 0)
+
+	now = now.Add(50 * time.Millisecond)
+	sys.Update(now)
+	is.Equal(calls, 0)
+
+	now = now.Add(50 * time.Millisecond)
+	sys.Update(now)
+	is.Equal(calls, 1)
+
+	// a long frame catches up on all intervals
+	now = now.Add(350 * time.Millisecond)
+	sys.Update(now)
+	is.Equal(calls, 4)
+
+	sys.Reset()
+
+	now = now.Add(time.Second)
+	sys.Update(now)
+	is.Equal(calls, 4)
+
+	now = now.Add(100 * time.Millisecond)
+	sys.Update(now)
+	is.Equal(calls, 5)
+}
+
+func TestParticleSystem_Every_Late(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(time.Hour)
+	sys.Update(now)
+
+	calls := 0
+	sys.Every(time.Second, func(s *ParticleSystem) {
+		calls++
+	})
+
+	now = now.Add(500 * time.Millisecond)
+	sys.Update(now)
+	is.Equal(calls, 0)
+
+	now = now.Add(500 * time.Millisecond)
+	sys.Update(now)
+	is.Equal(calls, 1)
+
+	sys.Reset()
+
+	sys.Every(time.Second, func(s *ParticleSystem) {
+		calls++
+	})
+
+	now = now.Add(time.Hour)
+	sys.Update(now)
+	is.Equal(calls, 1)
+
+	now = now.Add(time.Second)
+	sys.Update(now)
+	is.Equal(calls, 3)
+}
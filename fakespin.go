@@ -0,0 +1,87 @@
+package twodeeparticles
+
+import (
+	"math"
+	"time"
+)
+
+// A FakeSpin makes particles appear to spin around the X and Y axes in 3D, for example to make coins or cards tumble.
+// The rotation is simulated by modulating the scales of particles: A rotation around the Y axis foreshortens a sprite
+// along the X axis, and a rotation around the X axis foreshortens it along the Y axis. When a particle has rotated
+// far enough that its back side is facing the viewer, the corresponding scale becomes negative, which mirrors
+// the sprite just like a real rotation would (see FrontFacing.)
+type FakeSpin struct {
+	// Speed is the angular velocity of particles around the X and Y axes, in radians per second.
+	Speed Vector
+
+	// SpeedVariance randomly reduces the speed of each particle, as a fraction in the range [0.0,1.0]. For example,
+	// a SpeedVariance of 0.5 makes particles spin at between 50% and 100% of Speed.
+	SpeedVariance float64
+
+	// RandomPhase starts each particle at a random rotation around each axis that it spins around, so that particles
+	// do not spin in unison. Axes with a speed of zero are not affected.
+	RandomPhase bool
+
+	// RandomDirection makes each particle spin in a random direction around each axis.
+	RandomDirection bool
+}
+
+// Angles returns p's current rotation angles around the X and Y axes, in radians. The angles are based on p's age,
+// so that they also work for particles with an infinite lifetime.
+func (f FakeSpin) Angles(p *Particle) Vector {
+	sec := p.Age().Seconds()
+
+	return Vector{
+		f.angle(p, f.Speed.X, sec, randomFakeSpinSpeedX, randomFakeSpinPhaseX, randomFakeSpinDirectionX),
+		f.angle(p, f.Speed.Y, sec, randomFakeSpinSpeedY, randomFakeSpinPhaseY, randomFakeSpinDirectionY),
+	}
+}
+
+func (f FakeSpin) angle(p *Particle, speed float64, sec float64, speedChannel int, phaseChannel int, dirChannel int) float64 {
+	if speed == 0.0 {
+		return 0.0
+	}
+
+	v := math.Max(0.0, math.Min(f.SpeedVariance, 1.0))
+	speed *= 1.0 - v*p.Random(speedChannel)
+
+	if f.RandomDirection && p.Random(dirChannel) < 0.5 {
+		speed = -speed
+	}
+
+	phase := 0.0
+	if f.RandomPhase {
+		phase = p.Random(phaseChannel) * 2.0 * math.Pi
+	}
+
+	return speed*sec + phase
+}
+
+// Factors returns the multipliers for p's scale, in the range [-1.0,1.0]. X is the foreshortening along the X axis
+// caused by the rotation around the Y axis, and vice versa.
+func (f FakeSpin) Factors(p *Particle) Vector {
+	a := f.Angles(p)
+	return Vector{math.Cos(a.Y), math.Cos(a.X)}
+}
+
+// FrontFacing returns whether p's front side is currently facing the viewer. This can be used to draw a different
+// sprite for the back side, for example for cards.
+func (f FakeSpin) FrontFacing(p *Particle) bool {
+	fac := f.Factors(p)
+	return fac.X*fac.Y >= 0.0
+}
+
+// ScaleOverLifetime returns a function that can be used as ParticleSystem.ScaleOverLifetime. It modulates the scales
+// returned by fun. If fun is nil, (1.0,1.0) is modulated.
+func (f FakeSpin) ScaleOverLifetime(fun ParticleVectorOverNormalizedTimeFunc) ParticleVectorOverNormalizedTimeFunc {
+	return func(p *Particle, t NormalizedDuration, delta time.Duration) Vector {
+		s := OneVector
+		if fun != nil {
+			s = fun(p, t, delta)
+		}
+
+		fac := f.Factors(p)
+
+		return Vector{s.X * fac.X, s.Y * fac.Y}
+	}
+}
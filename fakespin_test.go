@@ -0,0 +1,80 @@
+package twodeeparticles
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestFakeSpin_ScaleOverLifetime(t *testing.T) {
+	is := is.New(t)
+
+	spin := FakeSpin{Speed: Vector{0.0, math.Pi}}
+
+	sys := NewSystem()
+	sys.MaxParticles = 1
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return InfiniteLifetime
+	}
+	sys.ScaleOverLifetime = spin.ScaleOverLifetime(func(p *Particle, t NormalizedDuration, delta time.Duration) Vector {
+		return Vector{2.0, 3.0}
+	})
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	expected := []Vector{{0.0, 3.0}, {-2.0, 3.0}, {0.0, 3.0}, {2.0, 3.0}}
+	for _, e := range expected {
+		now = now.Add(500 * time.Millisecond)
+		sys.Update(now)
+
+		sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+			is.True(approxEqualVector(p.Scale(), e))
+
+			if e.X != 0.0 {
+				is.Equal(spin.FrontFacing(p), e.X > 0.0)
+			}
+		}, now)
+	}
+}
+
+func TestFakeSpin_Angles_Random(t *testing.T) {
+	is := is.New(t)
+
+	spin := FakeSpin{Speed: Vector{10.0, 0.0}, SpeedVariance: 0.5, RandomDirection: true}
+
+	sys := NewSystem()
+	sys.MaxParticles = 50
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return InfiniteLifetime
+	}
+	sys.Spawn(50)
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	positive := false
+	negative := false
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		a := spin.Angles(p)
+		is.Equal(spin.Angles(p), a)
+		is.True(math.Abs(a.X) >= 5.0 && math.Abs(a.X) <= 10.0)
+		is.Equal(a.Y, 0.0)
+
+		if a.X > 0.0 {
+			positive = true
+		} else {
+			negative = true
+		}
+	}, now)
+
+	is.True(positive)
+	is.True(negative)
+}
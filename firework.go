@@ -0,0 +1,220 @@
+package twodeeparticles
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// A Firework is a preset that simulates firework shells being launched, and bursting into a sphere of sparks that
+// fall down and fade out. Shells rise as streaks, and burst when their fuse has burnt down, using a timer (see
+// Particle.After) and Particle.Split.
+//
+// The Data of a firework particle is a *FireworkParticle.
+type Firework struct {
+	// Palette is the palette that the colors of bursts are randomly chosen from. All sparks of a burst have the same
+	// color.
+	Palette Palette
+
+	// LaunchSpeed is the launch speed of shells, in arbitrary units per second.
+	LaunchSpeed float64
+
+	// LaunchSpeedVariance is the maximum random deviation from LaunchSpeed, in arbitrary units per second.
+	LaunchSpeedVariance float64
+
+	// LaunchSpread is the width of the cone around straight up that shells are launched into, in radians.
+	LaunchSpread float64
+
+	// Fuse is the time after which shells burst.
+	Fuse time.Duration
+
+	// FuseVariance is the maximum random deviation from Fuse.
+	FuseVariance time.Duration
+
+	// Sparks is the number of sparks that a shell bursts into.
+	Sparks int
+
+	// SparkSpeed is the speed of sparks relative to their shell, in arbitrary units per second. Since the sparks
+	// of a burst form a sphere that is viewed from the side, most sparks are slower than SparkSpeed.
+	SparkSpeed float64
+
+	// SparkSpeedVariance is the maximum random deviation from SparkSpeed, in arbitrary units per second.
+	SparkSpeedVariance float64
+
+	// SparkLifetime is the lifetime of sparks. Sparks fade out over their lifetime.
+	SparkLifetime time.Duration
+
+	// SparkLifetimeVariance is the maximum random deviation from SparkLifetime.
+	SparkLifetimeVariance time.Duration
+
+	// Gravity is the acceleration of shells and sparks, in arbitrary units per second squared.
+	Gravity Vector
+
+	// Drag is the fraction of velocity that sparks lose per second, in the range [0.0,1.0]. Shells are not slowed
+	// down by drag.
+	Drag float64
+
+	// ShellSize is the scale of shells.
+	ShellSize float64
+
+	// Streak is the factor that shells are stretched by vertically at LaunchSpeed, which makes them appear as streaks
+	// while rising. Shells are stretched less as they slow down.
+	Streak float64
+
+	// SparkSize is the scale of sparks.
+	SparkSize float64
+
+	sys  *ParticleSystem
+	rand *rand.Rand
+}
+
+// A FireworkParticle is the data attached to a firework particle.
+type FireworkParticle struct {
+	spark bool
+	color color.Color
+}
+
+// NewFirework returns a new firework effect. To launch shells, use Launch. To launch shells continuously,
+// set the system's EmissionRateOverTime (see System.)
+func NewFirework(maxParticles int, rand *rand.Rand) *Firework {
+	f := Firework{
+		Palette: Palette{
+			Colors: []color.Color{
+				color.RGBA{0xff, 0x4d, 0x4d, 0xff},
+				color.RGBA{0xff, 0xd1, 0x4d, 0xff},
+				color.RGBA{0x4d, 0xff, 0x88, 0xff},
+				color.RGBA{0x4d, 0xc3, 0xff, 0xff},
+				color.RGBA{0xc7, 0x7d, 0xff, 0xff},
+			},
+		},
+		LaunchSpeed:           350.0,
+		LaunchSpeedVariance:   30.0,
+		LaunchSpread:          math.Pi / 8.0,
+		Fuse:                  1400 * time.Millisecond,
+		FuseVariance:          200 * time.Millisecond,
+		Sparks:                80,
+		SparkSpeed:            150.0,
+		SparkSpeedVariance:    20.0,
+		SparkLifetime:         1500 * time.Millisecond,
+		SparkLifetimeVariance: 300 * time.Millisecond,
+		Gravity:               Vector{0.0, 150.0},
+		Drag:                  0.5,
+		ShellSize:             0.25,
+		Streak:                3.0,
+		SparkSize:             0.15,
+		sys:                   NewSystem(),
+		rand:                  rand,
+	}
+
+	f.sys.MaxParticles = maxParticles
+	f.sys.LifetimeOverTime = f.lifetime
+	f.sys.UpdateFunc = f.lightFuse
+	f.sys.InitialVelocityOverTime = f.initialVelocity
+	f.sys.DataOverLifetime = f.data
+	f.sys.VelocityOverLifetime = f.velocity
+	f.sys.ScaleOverLifetime = f.scale
+	f.sys.ColorOverLifetime = f.color
+
+	return &f
+}
+
+// System returns the particle system that simulates f.
+func (f *Firework) System() *ParticleSystem {
+	return f.sys
+}
+
+// Launch launches num shells on the next Update.
+func (f *Firework) Launch(num int) {
+	f.sys.SpawnBurst(Burst{Count: num})
+}
+
+// Spark returns whether p is a spark, as opposed to a shell.
+func (p *FireworkParticle) Spark() bool {
+	return p.spark
+}
+
+// lifetime returns the lifetime of shells. Shells live until they burst.
+func (f *Firework) lifetime(d time.Duration, delta time.Duration) time.Duration {
+	return InfiniteLifetime
+}
+
+func (f *Firework) initialVelocity(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+	a := -math.Pi/2.0 + (f.rand.Float64()-0.5)*f.LaunchSpread
+	s := f.LaunchSpeed + (f.rand.Float64()*2.0-1.0)*f.LaunchSpeedVariance
+
+	return VectorFromAngle(a).Multiply(s)
+}
+
+func (f *Firework) data(old any, t NormalizedDuration, delta time.Duration) any {
+	if old != nil {
+		return old
+	}
+
+	return &FireworkParticle{}
+}
+
+// lightFuse schedules new shells to burst. Sparks already have data when they are spawned.
+func (f *Firework) lightFuse(p *Particle, t NormalizedDuration, delta time.Duration) {
+	if p.updated || p.Data() != nil {
+		return
+	}
+
+	p.After(f.Fuse+time.Duration((f.rand.Float64()*2.0-1.0)*float64(f.FuseVariance)), f.burst)
+}
+
+func (f *Firework) velocity(p *Particle, t NormalizedDuration, delta time.Duration) Vector {
+	part := p.Data().(*FireworkParticle) //nolint:forcetypeassert // we know this is a *FireworkParticle
+
+	sec := delta.Seconds()
+	v := p.Velocity().Add(f.Gravity.Multiply(sec))
+
+	if !part.spark {
+		return v
+	}
+
+	return v.Multiply(math.Max(0.0, 1.0-f.Drag*sec))
+}
+
+// burst splits shell p into sparks.
+func (f *Firework) burst(p *Particle) {
+	col := f.Palette.Sample(f.rand)
+
+	p.Split(f.Sparks, func(parent *Particle, idx int, state *ParticleState) {
+		// sparks on the surface of a sphere, viewed from the side
+		z := f.rand.Float64()*2.0 - 1.0
+		s := (f.SparkSpeed + (f.rand.Float64()*2.0-1.0)*f.SparkSpeedVariance) * math.Sqrt(1.0-z*z)
+
+		state.Velocity = state.Velocity.Add(VectorFromAngle(f.rand.Float64() * 2.0 * math.Pi).Multiply(s))
+		state.Lifetime = f.SparkLifetime + time.Duration((f.rand.Float64()*2.0-1.0)*float64(f.SparkLifetimeVariance))
+		state.Data = &FireworkParticle{
+			spark: true,
+			color: col,
+		}
+	}, true)
+}
+
+func (f *Firework) scale(p *Particle, t NormalizedDuration, delta time.Duration) Vector {
+	part := p.Data().(*FireworkParticle) //nolint:forcetypeassert // we know this is a *FireworkParticle
+
+	if part.spark {
+		return Vector{f.SparkSize, f.SparkSize}
+	}
+
+	streak := 1.0
+	if f.LaunchSpeed > 0.0 {
+		streak += (f.Streak - 1.0) * math.Min(p.Velocity().Magnitude()/f.LaunchSpeed, 1.0)
+	}
+
+	return Vector{f.ShellSize, f.ShellSize * streak}
+}
+
+func (f *Firework) color(p *Particle, t NormalizedDuration, delta time.Duration) color.Color {
+	part := p.Data().(*FireworkParticle) //nolint:forcetypeassert // we know this is a *FireworkParticle
+
+	if !part.spark {
+		return color.White
+	}
+
+	return fadeColor(part.color, 1.0-float64(t))
+}
@@ -0,0 +1,58 @@
+package twodeeparticles
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestFirework_Launch(t *testing.T) {
+	is := is.New(t)
+
+	f := NewFirework(1000, rand.New(rand.NewSource(1))) //nolint:gosec // no crypto
+	f.Palette = Palette{Colors: []color.Color{color.RGBA{0x12, 0x23, 0x34, 0xff}}}
+	f.Sparks = 50
+
+	f.Launch(2)
+
+	now := time.Now()
+	f.System().Update(now)
+
+	is.Equal(f.System().NumParticles(), 2)
+
+	f.System().ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.True(!p.Data().(*FireworkParticle).Spark()) //nolint:forcetypeassert // we know this is a *FireworkParticle
+		is.Equal(p.Color(), color.White)
+
+		a := math.Atan2(p.Velocity().Y, p.Velocity().X)
+		is.True(a >= -math.Pi/2.0-f.LaunchSpread/2.0 && a <= -math.Pi/2.0+f.LaunchSpread/2.0)
+		is.True(p.Scale().Y > p.Scale().X)
+	}, now)
+
+	for i := 0; i < 20; i++ {
+		now = now.Add(100 * time.Millisecond)
+		f.System().Update(now)
+	}
+
+	// both shells have burst
+	is.Equal(f.System().NumParticles(), 100)
+
+	f.System().ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.True(p.Data().(*FireworkParticle).Spark()) //nolint:forcetypeassert // we know this is a *FireworkParticle
+		is.Equal(p.Scale(), Vector{f.SparkSize, f.SparkSize})
+
+		_, _, _, a := p.Color().RGBA()
+		is.True(a < 0xffff)
+	}, now)
+
+	for i := 0; i < 20; i++ {
+		now = now.Add(100 * time.Millisecond)
+		f.System().Update(now)
+	}
+
+	is.Equal(f.System().NumParticles(), 0)
+}
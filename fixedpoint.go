@@ -0,0 +1,95 @@
+package twodeeparticles
+
+import (
+	"math"
+	"math/bits"
+	"time"
+)
+
+const (
+	// fixedPointOne is the fixed-point representation of 1.0 used in FixedPoint mode.
+	fixedPointOne = 1 << 16
+
+	// fixedPointMax is the maximum absolute fixed-point value. Larger values are clamped. Values up to this magnitude
+	// can be represented exactly as float64.
+	fixedPointMax = 1 << 52
+)
+
+// quantize rounds v to the fixed-point grid if FixedPoint is set. Otherwise, it returns v unchanged.
+func (sys *ParticleSystem) quantize(v Vector) Vector {
+	if !sys.FixedPoint {
+		return v
+	}
+
+	return Vector{fromFixed(toFixed(v.X)), fromFixed(toFixed(v.Y))}
+}
+
+// integrate returns v times the duration d, in seconds. If FixedPoint is set, the computation is done using integer
+// math, and the result is on the fixed-point grid.
+func (sys *ParticleSystem) integrate(v Vector, d time.Duration) Vector {
+	if !sys.FixedPoint {
+		return v.Multiply(d.Seconds())
+	}
+
+	return Vector{fromFixed(fixedMulDuration(toFixed(v.X), d)), fromFixed(fixedMulDuration(toFixed(v.Y), d))}
+}
+
+// toFixed converts f to fixed-point, rounding to the nearest value, and clamping to the representable range.
+// NaN is converted to 0.
+func toFixed(f float64) int64 {
+	if math.IsNaN(f) {
+		return 0
+	}
+
+	f = math.RoundToEven(f * fixedPointOne)
+
+	switch {
+	case f > fixedPointMax:
+		return fixedPointMax
+	case f < -fixedPointMax:
+		return -fixedPointMax
+	default:
+		return int64(f)
+	}
+}
+
+// fromFixed converts the fixed-point value i to float64. The conversion is exact.
+func fromFixed(i int64) float64 {
+	return float64(i) / fixedPointOne
+}
+
+// fixedMulDuration returns the fixed-point value v times the duration d, in seconds. The result is truncated toward
+// zero, and clamped to the representable range.
+func fixedMulDuration(v int64, d time.Duration) int64 {
+	neg := (v < 0) != (d < 0)
+
+	hi, lo := bits.Mul64(absInt64(v), absInt64(int64(d)))
+	if hi >= uint64(time.Second) {
+		return withSign(fixedPointMax, neg)
+	}
+
+	q, _ := bits.Div64(hi, lo, uint64(time.Second))
+	if q > fixedPointMax {
+		q = fixedPointMax
+	}
+
+	return withSign(int64(q), neg)
+}
+
+// withSign returns -i if neg is set, otherwise i.
+func withSign(i int64, neg bool) int64 {
+	if neg {
+		return -i
+	}
+
+	return i
+}
+
+// absInt64 returns the absolute value of i.
+func absInt64(i int64) uint64 {
+	if i < 0 {
+		return uint64(-i)
+	}
+
+	return uint64(i)
+}
@@ -0,0 +1,71 @@
+package twodeeparticles
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestFixedMulDuration(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(fixedMulDuration(3*fixedPointOne, 500*time.Millisecond), int64(1.5*fixedPointOne))
+	is.Equal(fixedMulDuration(-3*fixedPointOne, 500*time.Millisecond), int64(-1.5*fixedPointOne))
+	is.Equal(fixedMulDuration(1, time.Millisecond), int64(0))
+	is.Equal(fixedMulDuration(fixedPointMax, time.Duration(math.MaxInt64)), int64(fixedPointMax))
+	is.Equal(fixedMulDuration(-fixedPointMax, time.Duration(math.MaxInt64)), int64(-fixedPointMax))
+}
+
+func TestToFixed(t *testing.T) {
+	is := is.New(t)
+
+	is.Equal(toFixed(1.0), int64(fixedPointOne))
+	is.Equal(toFixed(-0.5), int64(-fixedPointOne/2))
+	is.Equal(toFixed(math.NaN()), int64(0))
+	is.Equal(toFixed(math.Inf(1)), int64(fixedPointMax))
+	is.Equal(toFixed(math.Inf(-1)), int64(-fixedPointMax))
+}
+
+func TestParticleSystem_Update_FixedPoint(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.FixedPoint = true
+	sys.MaxParticles = 1
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return InfiniteLifetime
+	}
+	sys.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+		return Vector{1.0 / 3.0, -2.0 / 3.0}
+	}
+	sys.AccelerationOverLifetime = func(p *Particle, t NormalizedDuration, delta time.Duration) Vector {
+		return Vector{0.1, 0}
+	}
+
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	for i := 0; i < 100; i++ {
+		now = now.Add(16667 * time.Microsecond)
+		sys.Update(now)
+	}
+
+	onGrid := func(f float64) bool {
+		return f*fixedPointOne == math.Trunc(f*fixedPointOne)
+	}
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.True(onGrid(p.Position().X))
+		is.True(onGrid(p.Position().Y))
+		is.True(onGrid(p.Velocity().X))
+		is.True(onGrid(p.Velocity().Y))
+
+		// close to the exact result after 1.6667s
+		is.True(math.Abs(p.Position().X-(1.6667/3.0+0.05*1.6667*1.6667)) < 1e-3)
+		is.True(math.Abs(p.Position().Y+1.6667*2.0/3.0) < 1e-3)
+	}, now)
+}
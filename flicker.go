@@ -0,0 +1,93 @@
+package twodeeparticles
+
+import (
+	"image/color"
+	"math"
+	"time"
+)
+
+// A Flicker modulates the opacity or scale of particles over time, for example to make candle flames, embers,
+// or neon lights flicker.
+type Flicker struct {
+	// Wave is the waveform that is used for modulation.
+	Wave FlickerWave
+
+	// Frequency is the frequency of the modulation, in Hertz. For FlickerNoise, it is the number of random values
+	// per second that are interpolated.
+	Frequency float64
+
+	// Amplitude is the strength of the modulation, in the range [0.0,1.0]. At its minimum, the modulation multiplies
+	// values by 1.0-Amplitude, at its maximum, it multiplies values by 1.0.
+	Amplitude float64
+
+	// RandomPhase shifts the modulation by a random amount for each particle, so that particles do not flicker
+	// in unison.
+	RandomPhase bool
+}
+
+// FlickerWave is a waveform used by Flicker.
+type FlickerWave int
+
+const (
+	// FlickerSine modulates values using a sine wave.
+	FlickerSine FlickerWave = iota
+
+	// FlickerNoise modulates values using smooth random noise.
+	FlickerNoise
+)
+
+// Factor returns the multiplier for p's values, in the range [1.0-Amplitude,1.0]. The modulation is based on p's age,
+// so that it also works for particles with an infinite lifetime.
+func (f Flicker) Factor(p *Particle) float64 {
+	x := p.Age().Seconds() * f.Frequency
+
+	phase := 0.0
+	if f.RandomPhase {
+		phase = p.Random(randomFlickerPhase)
+	}
+
+	var w float64
+
+	switch f.Wave {
+	case FlickerNoise:
+		var seed uint64
+		if f.RandomPhase {
+			seed = p.seed
+		}
+
+		w = Noise{Seed: seed}.At(x + phase)
+
+	default:
+		w = 0.5 + 0.5*math.Sin(2.0*math.Pi*(x+phase))
+	}
+
+	a := math.Max(0.0, math.Min(f.Amplitude, 1.0))
+
+	return 1.0 - a*(1.0-w)
+}
+
+// ColorOverLifetime returns a function that can be used as ParticleSystem.ColorOverLifetime. It modulates the opacity
+// of the colors returned by fun. If fun is nil, color.White is modulated.
+func (f Flicker) ColorOverLifetime(fun ParticleColorOverNormalizedTimeFunc) ParticleColorOverNormalizedTimeFunc {
+	return func(p *Particle, t NormalizedDuration, delta time.Duration) color.Color {
+		c := white
+		if fun != nil {
+			c = fun(p, t, delta)
+		}
+
+		return fadeColor(c, f.Factor(p))
+	}
+}
+
+// ScaleOverLifetime returns a function that can be used as ParticleSystem.ScaleOverLifetime. It modulates the scales
+// returned by fun. If fun is nil, (1.0,1.0) is modulated.
+func (f Flicker) ScaleOverLifetime(fun ParticleVectorOverNormalizedTimeFunc) ParticleVectorOverNormalizedTimeFunc {
+	return func(p *Particle, t NormalizedDuration, delta time.Duration) Vector {
+		s := OneVector
+		if fun != nil {
+			s = fun(p, t, delta)
+		}
+
+		return s.Multiply(f.Factor(p))
+	}
+}
@@ -0,0 +1,52 @@
+package twodeeparticles
+
+import (
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestFlicker_Factor(t *testing.T) {
+	is := is.New(t)
+
+	p := &Particle{seed: 1234}
+	f := Flicker{Frequency: 1.0, Amplitude: 0.5}
+
+	p.age = 250 * time.Millisecond
+	is.True(approxEqual(f.Factor(p), 1.0))
+
+	p.age = 750 * time.Millisecond
+	is.True(approxEqual(f.Factor(p), 0.5))
+
+	f.Wave = FlickerNoise
+	f.RandomPhase = true
+
+	for i := 0; i < 100; i++ {
+		p.age = time.Duration(i) * 37 * time.Millisecond
+		v := f.Factor(p)
+		is.True(v >= 0.5 && v <= 1.0)
+	}
+}
+
+func TestFlicker_ColorOverLifetime(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 1
+	sys.ColorOverLifetime = Flicker{Frequency: 1.0, Amplitude: 1.0}.ColorOverLifetime(nil)
+	sys.ScaleOverLifetime = Flicker{Frequency: 1.0, Amplitude: 0.5}.ScaleOverLifetime(nil)
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(750 * time.Millisecond)
+	sys.Update(now)
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.Equal(p.Color(), color.RGBA64{0, 0, 0, 0})
+		is.True(approxEqualVector(p.Scale(), Vector{0.5, 0.5}))
+	}, now)
+}
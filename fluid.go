@@ -0,0 +1,133 @@
+package twodeeparticles
+
+import (
+	"math"
+	"time"
+)
+
+// A Fluid makes particles behave like a simplified fluid, using a lightweight form of smoothed particle hydrodynamics.
+// Particles that are packed more densely than the rest density push each other apart, particles that are packed less
+// densely pull each other together, and viscosity evens out the velocities of neighboring particles. This makes
+// effects such as water or lava splashes behave cohesively instead of as a spray of independent drops.
+//
+// The simulation is not physically accurate, and is tuned for stability rather than realism. If particles jitter
+// or explode, Stiffness should be lowered, or MaxAcceleration should be set.
+type Fluid struct {
+	// Radius is the distance within which particles interact with each other. If Radius is 0, particles do not
+	// behave like a fluid.
+	Radius float64
+
+	// RestDensity is the density that particles try to maintain. The density around a particle is the sum of
+	// the weights of its neighbors, including itself, where the weight decreases from 1.0 at the particle's position
+	// to 0.0 at Radius.
+	RestDensity float64
+
+	// Stiffness is the strength with which particles push apart or pull together to maintain RestDensity,
+	// in arbitrary units per second squared.
+	Stiffness float64
+
+	// Viscosity is the rate at which the velocities of neighboring particles are evened out, per second.
+	// A viscosity of 0 makes the fluid splash freely, higher values make it move like honey.
+	Viscosity float64
+
+	// MaxNeighbors limits the number of neighbors that are taken into account per particle, to limit the cost of
+	// the simulation in very dense regions. If MaxNeighbors is 0, all neighbors are taken into account.
+	MaxNeighbors int
+
+	// MaxAcceleration limits the velocity change per second of a particle caused by the fluid, to prevent
+	// the simulation from becoming unstable. If MaxAcceleration is 0, the velocity change is not limited.
+	MaxAcceleration float64
+}
+
+// applyFluid changes the velocities of particles according to Fluid.
+func (sys *ParticleSystem) applyFluid(now time.Time) {
+	fluid := sys.Fluid
+	if fluid.Radius <= 0 {
+		return
+	}
+
+	sec := now.Sub(sys.lastUpdateTime).Seconds()
+	if sec <= 0 {
+		return
+	}
+
+	sys.buildGrid(fluid.Radius)
+
+	for _, p := range sys.particles {
+		if !p.isAlive {
+			continue
+		}
+
+		density := 0.0
+
+		fluid.forEachNeighbor(sys, p, func(other *Particle, diff Vector, dist float64) {
+			w := 1.0 - dist/fluid.Radius
+			density += w * w
+		})
+
+		p.pressure = fluid.Stiffness * (density - fluid.RestDensity)
+	}
+
+	if cap(sys.fluidAccelerations) < len(sys.particles) {
+		sys.fluidAccelerations = make([]Vector, len(sys.particles))
+	}
+
+	accels := sys.fluidAccelerations[:len(sys.particles)]
+
+	for idx, p := range sys.particles {
+		accels[idx] = ZeroVector
+
+		if !p.isAlive {
+			continue
+		}
+
+		accel := ZeroVector
+
+		fluid.forEachNeighbor(sys, p, func(other *Particle, diff Vector, dist float64) {
+			if other == p {
+				return
+			}
+
+			w := 1.0 - dist/fluid.Radius
+
+			dir, ok := diff.TryNormalize()
+			if !ok {
+				// same position, push apart in a direction that is different for each particle
+				dir = VectorFromAngle(p.Random(randomFluidAngle) * 2.0 * math.Pi).Multiply(-1.0)
+			}
+
+			accel = accel.Add(dir.Multiply(-(p.pressure + other.pressure) / 2.0 * w))
+			accel = accel.Add(other.velocity.Add(p.velocity.Multiply(-1.0)).Multiply(fluid.Viscosity * w))
+		})
+
+		if fluid.MaxAcceleration > 0 {
+			if m := accel.Magnitude(); m > fluid.MaxAcceleration {
+				accel = accel.Multiply(fluid.MaxAcceleration / m)
+			}
+		}
+
+		accels[idx] = accel
+	}
+
+	for idx, p := range sys.particles {
+		p.velocity = p.velocity.Add(accels[idx].Multiply(sec))
+	}
+}
+
+// forEachNeighbor calls fun for each particle within Radius around p, including p itself, up to MaxNeighbors
+// particles.
+func (f Fluid) forEachNeighbor(sys *ParticleSystem, p *Particle, fun func(other *Particle, diff Vector, dist float64)) {
+	num := 0
+
+	sys.forEachNear(p.position, f.Radius, func(other *Particle, diff Vector, dist float64) {
+		if f.MaxNeighbors > 0 && num >= f.MaxNeighbors && other != p {
+			return
+		}
+
+		if other != p {
+			num++
+		}
+
+		fun(other, diff, dist)
+	})
+}
@@ -0,0 +1,111 @@
+package twodeeparticles
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestParticleSystem_Update_Fluid(t *testing.T) {
+	is := is.New(t)
+
+	// each particle has a density of 1.0 + (1.0 - 2.0/4.0)^2 = 1.25
+	velocities := updateFluid(Fluid{Radius: 4.0, RestDensity: 1.0, Stiffness: 10.0})
+	is.True(approxEqualVector(velocities[0], Vector{-0.125, 0}))
+	is.True(approxEqualVector(velocities[1], Vector{0.125, 0}))
+	is.True(approxEqualVector(velocities[2], ZeroVector))
+
+	velocities = updateFluid(Fluid{Radius: 4.0, RestDensity: 2.0, Stiffness: 10.0})
+	is.True(approxEqualVector(velocities[0], Vector{0.375, 0}))
+	is.True(approxEqualVector(velocities[1], Vector{-0.375, 0}))
+
+	velocities = updateFluid(Fluid{Radius: 4.0, RestDensity: 1.0, Stiffness: 10.0, MaxAcceleration: 1.0})
+	is.True(approxEqualVector(velocities[0], Vector{-0.1, 0}))
+	is.True(approxEqualVector(velocities[1], Vector{0.1, 0}))
+
+	velocities = updateFluid(Fluid{Radius: 4.0, RestDensity: 1.0, Stiffness: 10.0, MaxNeighbors: 1})
+	is.True(approxEqualVector(velocities[0], Vector{-0.125, 0}))
+}
+
+func TestParticleSystem_Update_Fluid_Viscosity(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 2
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return InfiniteLifetime
+	}
+	sys.Fluid = Fluid{Radius: 4.0, Viscosity: 2.0}
+
+	sys.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) Vector {
+		return ZeroVector
+	}
+
+	first := true
+
+	sys.UpdateFunc = func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		if p.Age() > 0 {
+			return
+		}
+
+		if first {
+			p.velocity = Vector{1, 0}
+			first = false
+		}
+	}
+
+	sys.Spawn(2)
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(100 * time.Millisecond)
+	sys.Update(now)
+
+	var velocities []Vector
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		velocities = append(velocities, p.Velocity())
+	}, now)
+
+	// velocities are evened out, and the particles have moved apart slightly
+	is.True(velocities[0].X < 1.0)
+	is.True(velocities[1].X > 0.0)
+	is.True(approxEqual(velocities[0].X+velocities[1].X, 1.0))
+}
+
+func updateFluid(fluid Fluid) []Vector {
+	positions := []Vector{{0, 0}, {2, 0}, {10, 0}}
+
+	sys := NewSystem()
+	sys.MaxParticles = len(positions)
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return InfiniteLifetime
+	}
+	sys.Fluid = fluid
+
+	idx := 0
+	sys.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) Vector {
+		pos := positions[idx]
+		idx++
+
+		return pos
+	}
+
+	sys.Spawn(len(positions))
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(100 * time.Millisecond)
+	sys.Update(now)
+
+	var velocities []Vector
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		velocities = append(velocities, p.Velocity())
+	}, now)
+
+	return velocities
+}
@@ -0,0 +1,109 @@
+package twodeeparticles
+
+// Falloff specifies how the strength of an effect decreases with the distance from its origin.
+type Falloff int
+
+const (
+	// FalloffNone does not decrease strength with distance.
+	FalloffNone Falloff = iota
+
+	// FalloffLinear decreases strength linearly with distance, reaching zero at the effect's radius.
+	FalloffLinear
+
+	// FalloffQuadratic decreases strength quadratically with distance, reaching zero at the effect's radius.
+	FalloffQuadratic
+)
+
+// ApplyImpulse instantly adds velocity to all particles within radius around origin, pointing away from origin.
+// strength is the velocity added to particles at origin, in arbitrary units per second, and decreases with
+// the distance from origin according to falloff. Particles exactly at origin are not affected.
+//
+// Depending on VelocityOverLifetime, the impulse may be overwritten on the next update (see
+// ParticleSystem.VelocityOverLifetime.)
+func (sys *ParticleSystem) ApplyImpulse(origin Vector, strength float64, radius float64, falloff Falloff) {
+	for _, p := range sys.particles {
+		if !p.isAlive {
+			continue
+		}
+
+		diff := sys.offset(origin, p.position)
+
+		dist := diff.Magnitude()
+		if dist > radius {
+			continue
+		}
+
+		dir, ok := diff.TryNormalize()
+		if !ok {
+			continue
+		}
+
+		p.velocity = p.velocity.Add(dir.Multiply(strength * falloff.factor(dist, radius)))
+	}
+}
+
+// ApplySuction instantly adds velocity to all particles within radius around target, pointing towards target.
+// strength is the velocity added to particles, in arbitrary units per second. Particles within killRadius around
+// target are killed. ApplySuction is usually called on every frame, for example to pull particles into a player
+// character and have them disappear on arrival.
+//
+// If VelocityOverLifetime does not build on particles' current velocities, the added velocity only lasts until
+// the next update (see ParticleSystem.VelocityOverLifetime.)
+func (sys *ParticleSystem) ApplySuction(target Vector, strength float64, radius float64, killRadius float64) {
+	for _, p := range sys.particles {
+		if !p.isAlive {
+			continue
+		}
+
+		diff := sys.offset(p.position, target)
+
+		dist := diff.Magnitude()
+		if dist > radius {
+			continue
+		}
+
+		if dist <= killRadius {
+			p.Kill()
+			continue
+		}
+
+		dir, ok := diff.TryNormalize()
+		if !ok {
+			continue
+		}
+
+		p.velocity = p.velocity.Add(dir.Multiply(strength))
+	}
+}
+
+// offset returns the vector pointing from v1 to v2. If WrapQueries is true, it returns the shortest such vector
+// when wrapping around WrapBounds.
+func (sys *ParticleSystem) offset(v1 Vector, v2 Vector) Vector {
+	diff := v2.Add(v1.Multiply(-1.0))
+
+	if !sys.WrapQueries {
+		return diff
+	}
+
+	size := Vector{sys.WrapBounds.Width(), sys.WrapBounds.Height()}
+
+	return Rect{size.Multiply(-0.5), size.Multiply(0.5)}.wrap(diff)
+}
+
+func (f Falloff) factor(dist float64, radius float64) float64 {
+	if radius <= 0 {
+		return 1.0
+	}
+
+	switch f {
+	case FalloffLinear:
+		return 1.0 - dist/radius
+
+	case FalloffQuadratic:
+		r := 1.0 - dist/radius
+		return r * r
+
+	default:
+		return 1.0
+	}
+}
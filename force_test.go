@@ -0,0 +1,112 @@
+package twodeeparticles
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestParticleSystem_ApplyImpulse(t *testing.T) {
+	is := is.New(t)
+
+	positions := []Vector{{10, 0}, {0, -20}, {30, 40}, {0, 0}}
+
+	sys := NewSystem()
+
+	sys.MaxParticles = len(positions)
+
+	idx := 0
+	sys.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) Vector {
+		pos := positions[idx]
+		idx++
+
+		return pos
+	}
+
+	sys.Spawn(len(positions))
+
+	now := time.Now()
+	sys.Update(now)
+
+	sys.ApplyImpulse(ZeroVector, 100.0, 40.0, FalloffLinear)
+
+	var velocities []Vector
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		velocities = append(velocities, p.Velocity())
+	}, now)
+
+	is.Equal(velocities, []Vector{{75, 0}, {0, -50}, {0, 0}, {0, 0}})
+}
+
+func TestFalloff_factor(t *testing.T) {
+	is := is.New(t)
+	is.Equal(FalloffNone.factor(5, 10), 1.0)
+	is.Equal(FalloffLinear.factor(5, 10), 0.5)
+	is.Equal(FalloffQuadratic.factor(5, 10), 0.25)
+}
+
+func TestParticleSystem_ApplySuction(t *testing.T) {
+	is := is.New(t)
+
+	positions := []Vector{{10, 0}, {0, -20}, {30, 40}, {1, 1}}
+
+	sys := NewSystem()
+
+	sys.MaxParticles = len(positions)
+
+	idx := 0
+	sys.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) Vector {
+		pos := positions[idx]
+		idx++
+
+		return pos
+	}
+
+	sys.Spawn(len(positions))
+
+	now := time.Now()
+	sys.Update(now)
+
+	sys.ApplySuction(ZeroVector, 100.0, 40.0, 5.0)
+
+	var velocities []Vector
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		velocities = append(velocities, p.Velocity())
+	}, now)
+
+	is.Equal(velocities[:3], []Vector{{-100, 0}, {0, 100}, {0, 0}})
+
+	now = now.Add(10 * time.Millisecond)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 3)
+}
+
+func TestParticleSystem_ApplyImpulse_WrapQueries(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+
+	sys.MaxParticles = 1
+
+	sys.WrapBounds = Rect{Vector{-100, -100}, Vector{100, 100}}
+	sys.WrapQueries = true
+
+	sys.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) Vector {
+		return Vector{-95, 0}
+	}
+
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	sys.ApplyImpulse(Vector{95, 0}, 100.0, 20.0, FalloffLinear)
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.True(approxEqualVector(p.Velocity(), Vector{50, 0}))
+	}, now)
+}
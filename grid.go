@@ -0,0 +1,116 @@
+package twodeeparticles
+
+import "math"
+
+// A spatialGrid sorts particles into square cells, so that particles near a position can be found quickly.
+type spatialGrid struct {
+	cellSize float64
+	cells    map[gridCell][]*Particle
+}
+
+type gridCell struct {
+	x int
+	y int
+}
+
+// build sorts all alive particles into cells of size cellSize. Cells are reused between builds to avoid allocations.
+func (g *spatialGrid) build(particles []*Particle, cellSize float64) {
+	if g.cells == nil {
+		g.cells = map[gridCell][]*Particle{}
+	}
+
+	for c, parts := range g.cells {
+		if len(parts) == 0 {
+			delete(g.cells, c)
+			continue
+		}
+
+		for idx := range parts {
+			parts[idx] = nil
+		}
+
+		g.cells[c] = parts[:0]
+	}
+
+	g.cellSize = cellSize
+
+	for _, p := range particles {
+		if !p.isAlive {
+			continue
+		}
+
+		c := g.cell(p.position)
+		g.cells[c] = append(g.cells[c], p)
+	}
+}
+
+func (g *spatialGrid) cell(v Vector) gridCell {
+	return gridCell{int(math.Floor(v.X / g.cellSize)), int(math.Floor(v.Y / g.cellSize))}
+}
+
+// forEachInBox calls fun for all particles in cells that intersect the square box of size 2*radius around pos.
+func (g *spatialGrid) forEachInBox(pos Vector, radius float64, fun func(p *Particle)) {
+	minCell := g.cell(Vector{pos.X - radius, pos.Y - radius})
+	maxCell := g.cell(Vector{pos.X + radius, pos.Y + radius})
+
+	for y := minCell.y; y <= maxCell.y; y++ {
+		for x := minCell.x; x <= maxCell.x; x++ {
+			for _, p := range g.cells[gridCell{x, y}] {
+				fun(p)
+			}
+		}
+	}
+}
+
+// buildGrid sorts the system's alive particles into its spatial grid, using cells of size cellSize.
+func (sys *ParticleSystem) buildGrid(cellSize float64) {
+	sys.grid.build(sys.particles, cellSize)
+}
+
+// forEachNear calls fun for all alive particles within radius around pos, using the spatial grid built by buildGrid.
+// diff is the vector pointing from pos to the particle, and dist is its length. If WrapQueries is true, particles
+// are also found across the edges of WrapBounds, as long as radius is less than half the size of WrapBounds.
+func (sys *ParticleSystem) forEachNear(pos Vector, radius float64, fun func(p *Particle, diff Vector, dist float64)) {
+	visit := func(p *Particle) {
+		diff := sys.offset(pos, p.position)
+
+		dist := diff.Magnitude()
+		if dist > radius {
+			return
+		}
+
+		fun(p, diff, dist)
+	}
+
+	if !sys.WrapQueries {
+		sys.grid.forEachInBox(pos, radius, visit)
+		return
+	}
+
+	b := sys.WrapBounds
+	dxs, numX := wrapShifts(pos.X, radius, b.Min.X, b.Max.X)
+	dys, numY := wrapShifts(pos.Y, radius, b.Min.Y, b.Max.Y)
+
+	for _, dy := range dys[:numY] {
+		for _, dx := range dxs[:numX] {
+			sys.grid.forEachInBox(pos.Add(Vector{dx, dy}), radius, visit)
+		}
+	}
+}
+
+// wrapShifts returns the shifts along an axis that a query around v with radius needs to be repeated at, so that
+// it finds particles across the edges of the range [min,max). It returns the shifts and their number.
+func wrapShifts(v float64, radius float64, min float64, max float64) ([2]float64, int) {
+	size := max - min
+
+	switch {
+	case size <= 0 || radius*2.0 >= size:
+		return [2]float64{0.0}, 1
+	case v-radius < min:
+		return [2]float64{0.0, size}, 2
+	case v+radius >= max:
+		return [2]float64{0.0, -size}, 2
+	default:
+		return [2]float64{0.0}, 1
+	}
+}
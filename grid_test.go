@@ -0,0 +1,60 @@
+package twodeeparticles
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestParticleSystem_ForEachNear(t *testing.T) {
+	is := is.New(t)
+
+	positions := []Vector{{0, 0}, {3, 4}, {-6, 0}, {9, 9}, {-9, -9}, {8.5, 0}}
+
+	sys := NewSystem()
+	sys.MaxParticles = len(positions)
+
+	idx := 0
+	sys.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) Vector {
+		pos := positions[idx]
+		idx++
+
+		return pos
+	}
+
+	sys.Spawn(len(positions))
+	sys.Update(time.Now())
+
+	near := func(pos Vector, radius float64) []Vector {
+		var found []Vector
+
+		sys.forEachNear(pos, radius, func(p *Particle, diff Vector, dist float64) {
+			is.True(approxEqual(diff.Magnitude(), dist))
+			found = append(found, p.Position())
+		})
+
+		sort.Slice(found, func(i int, j int) bool {
+			if found[i].X == found[j].X {
+				return found[i].Y < found[j].Y
+			}
+
+			return found[i].X < found[j].X
+		})
+
+		return found
+	}
+
+	sys.buildGrid(2.0)
+
+	is.Equal(near(ZeroVector, 5.0), []Vector{{0, 0}, {3, 4}})
+	is.Equal(near(Vector{-9, -9}, 1.0), []Vector{{-9, -9}})
+
+	sys.WrapBounds = Rect{Vector{-10, -10}, Vector{10, 10}}
+	sys.WrapQueries = true
+	sys.buildGrid(2.0)
+
+	is.Equal(near(Vector{9, 9}, 3.0), []Vector{{-9, -9}, {9, 9}})
+	is.Equal(near(Vector{-9, 0}, 4.0), []Vector{{-6, 0}, {8.5, 0}})
+}
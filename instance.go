@@ -0,0 +1,146 @@
+package twodeeparticles
+
+import (
+	"image/color"
+	"math"
+)
+
+// InstanceVariation varies the instances of a system (see ParticleSystem.Instances), so that instances sharing
+// a single simulation do not look perfectly synchronized. Variations are only applied when rendering. The variation
+// of each instance is random, but fixed for the instance's index and Seed.
+type InstanceVariation struct {
+	// MaxDelay is the maximum number of frames that an instance lags behind the simulation. Each instance shows
+	// the snapshot of a random number of frames ago, in the range [0,MaxDelay].
+	MaxDelay int
+
+	// TintVariance is the maximum amount that each color channel of an instance is darkened by, in the range
+	// [0.0,1.0]. For example, if TintVariance is 0.2, each channel is multiplied by a random value in [0.8,1.0].
+	TintVariance float64
+
+	// ScaleVariance is the maximum amount that the scale of an instance is changed by, in either direction.
+	// For example, if ScaleVariance is 0.1, the scale is multiplied by a random value in [0.9,1.1]. Particle positions
+	// relative to the instance's origin are scaled accordingly.
+	ScaleVariance float64
+
+	// Seed is the seed of the random variations.
+	Seed uint64
+}
+
+// Channels used with InstanceVariation.random.
+const (
+	instanceDelay = iota
+	instanceScale
+	instanceTintR
+	instanceTintG
+	instanceTintB
+)
+
+// An InstanceRenderer renders the instances of a system with variations (see InstanceVariation.) It keeps a short
+// history of snapshots, so that instances can lag behind the simulation. The zero value is ready to use.
+type InstanceRenderer struct {
+	// Variation is the variation of the instances.
+	Variation InstanceVariation
+
+	// history is the snapshot history, most recent first.
+	history []*RenderSnapshot
+}
+
+// Push adds snap to the history of snapshots. The renderer takes ownership of snap, and releases it when it is no longer
+// needed. Push should be called once per frame.
+func (r *InstanceRenderer) Push(snap *RenderSnapshot) {
+	r.history = append(r.history, nil)
+	copy(r.history[1:], r.history)
+	r.history[0] = snap
+
+	keep := r.Variation.MaxDelay + 1
+	if keep < 1 {
+		keep = 1
+	}
+
+	for idx := keep; idx < len(r.history); idx++ {
+		r.history[idx].Release()
+		r.history[idx] = nil
+	}
+
+	if len(r.history) > keep {
+		r.history = r.history[:keep]
+	}
+}
+
+// ForEachInstance calls fun for each particle of each instance, using the instances of the most recent snapshot
+// (see RenderSnapshot.ForEachInstance.) The particles are taken from the snapshot of each instance's delay, and their
+// scales, positions, and colors are varied. If no snapshot has been pushed yet, ForEachInstance does nothing.
+func (r *InstanceRenderer) ForEachInstance(fun RenderInstanceFunc) {
+	if len(r.history) == 0 {
+		return
+	}
+
+	instances := r.history[0].Instances
+	if len(instances) == 0 {
+		instances = []Vector{ZeroVector}
+	}
+
+	for idx, origin := range instances {
+		snap := r.history[r.Variation.delay(idx, len(r.history))]
+		scale := r.Variation.scale(idx)
+
+		for _, p := range snap.Particles {
+			p.Position = p.Position.Multiply(scale).Add(origin)
+			p.Scale = p.Scale.Multiply(scale)
+			p.Color = r.Variation.tint(idx, p.Color)
+
+			fun(idx, p)
+		}
+	}
+}
+
+// Release releases all snapshots in the history. r can be used again afterwards.
+func (r *InstanceRenderer) Release() {
+	for idx, snap := range r.history {
+		snap.Release()
+		r.history[idx] = nil
+	}
+
+	r.history = r.history[:0]
+}
+
+// delay returns the delay of instance idx, in frames, limited to the available history.
+func (v InstanceVariation) delay(idx int, historyLen int) int {
+	if v.MaxDelay <= 0 {
+		return 0
+	}
+
+	d := int(v.random(idx, instanceDelay) * float64(v.MaxDelay+1))
+
+	if d >= historyLen {
+		d = historyLen - 1
+	}
+
+	return d
+}
+
+// scale returns the scale multiplier of instance idx.
+func (v InstanceVariation) scale(idx int) float64 {
+	return 1.0 + (v.random(idx, instanceScale)*2.0-1.0)*v.ScaleVariance
+}
+
+// tint returns c, tinted according to the variation of instance idx.
+func (v InstanceVariation) tint(idx int, c color.Color) color.Color {
+	if v.TintVariance <= 0 || c == nil {
+		return c
+	}
+
+	r, g, b, a := c.RGBA()
+
+	mul := func(ch uint32, channel int) uint16 {
+		f := 1.0 - v.random(idx, channel)*math.Min(v.TintVariance, 1.0)
+		return uint16(float64(ch) * f)
+	}
+
+	return color.RGBA64{mul(r, instanceTintR), mul(g, instanceTintG), mul(b, instanceTintB), uint16(a)}
+}
+
+// random returns a random value in the range [0.0,1.0) that is fixed for instance idx and channel n.
+func (v InstanceVariation) random(idx int, n int) float64 {
+	return hashFloat(v.Seed + uint64(idx)*0x9e3779b97f4a7c15 + uint64(n)*0xc2b2ae3d27d4eb4f)
+}
@@ -0,0 +1,86 @@
+package twodeeparticles
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestInstanceRenderer_ForEachInstance(t *testing.T) {
+	is := is.New(t)
+
+	instances := make([]Vector, 20)
+	for idx := range instances {
+		instances[idx] = Vector{float64(idx) * 100, 0}
+	}
+
+	r := InstanceRenderer{
+		Variation: InstanceVariation{
+			MaxDelay: 2,
+			Seed:     1,
+		},
+	}
+
+	defer r.Release()
+
+	// frame n has a single particle at (n,0)
+	for frame := 0; frame < 5; frame++ {
+		r.Push(&RenderSnapshot{
+			Particles: []RenderParticle{{Position: Vector{float64(frame), 0}, Scale: OneVector, Color: color.White}},
+			Instances: instances,
+		})
+	}
+
+	is.Equal(len(r.history), 3)
+
+	delays := map[float64]bool{}
+
+	r.ForEachInstance(func(instance int, p RenderParticle) {
+		delay := 4 - (p.Position.X - float64(instance)*100)
+		is.Equal(delay, float64(r.Variation.delay(instance, 3)))
+
+		delays[delay] = true
+
+		is.Equal(p.Scale, OneVector)
+		is.Equal(p.Color, color.Color(color.White))
+	})
+
+	// all delays are used
+	is.Equal(len(delays), 3)
+}
+
+func TestInstanceRenderer_ForEachInstance_ScaleTint(t *testing.T) {
+	is := is.New(t)
+
+	r := InstanceRenderer{
+		Variation: InstanceVariation{
+			TintVariance:  0.5,
+			ScaleVariance: 0.25,
+		},
+	}
+
+	defer r.Release()
+
+	r.Push(&RenderSnapshot{
+		Particles: []RenderParticle{{Position: Vector{10, 0}, Scale: OneVector, Color: color.White}},
+		Instances: []Vector{{100, 0}, {200, 0}, {300, 0}},
+	})
+
+	num := 0
+
+	r.ForEachInstance(func(instance int, p RenderParticle) {
+		num++
+
+		scale := r.Variation.scale(instance)
+		is.True(scale >= 0.75 && scale <= 1.25)
+		is.True(approxEqual(p.Scale.X, scale))
+		is.True(approxEqualVector(p.Position, Vector{float64(instance+1)*100 + 10*scale, 0}))
+
+		cr, cg, cb, ca := p.Color.RGBA()
+		is.True(cr >= 0x7fff && cg >= 0x7fff && cb >= 0x7fff)
+		is.Equal(ca, uint32(0xffff))
+	})
+
+	is.Equal(num, 3)
+}
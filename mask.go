@@ -0,0 +1,121 @@
+package twodeeparticles
+
+import (
+	"image"
+	"math/rand"
+	"time"
+)
+
+// A MaskEdgeEmitter emits particles along the outline of the opaque area of an image, for example to create
+// "burning away" or highlight shimmer effects around a sprite. Particles can be emitted into the direction of
+// the outline's outward normals.
+type MaskEdgeEmitter struct {
+	points  []Vector
+	normals []Vector
+	indices map[Vector]int
+}
+
+// NewMaskEdgeEmitter returns a new emitter for the outline of the opaque area of img. A pixel is considered opaque
+// if its alpha is at least threshold, in the range [0.0,1.0]. The positions emitted are measured in pixels,
+// relative to img's top left corner, plus offset. For example, if the image is drawn centered at the system's
+// origin, offset should be minus half its size.
+func NewMaskEdgeEmitter(img image.Image, threshold float64, offset Vector) *MaskEdgeEmitter {
+	bounds := img.Bounds()
+	opaque := opaqueFunc(img, threshold)
+
+	e := MaskEdgeEmitter{
+		indices: map[Vector]int{},
+	}
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if !opaque(x, y) {
+				continue
+			}
+
+			normal := ZeroVector
+
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					if !opaque(x+dx, y+dy) {
+						normal = normal.Add(Vector{float64(dx), float64(dy)})
+					}
+				}
+			}
+
+			if !(opaque(x-1, y) && opaque(x+1, y) && opaque(x, y-1) && opaque(x, y+1)) {
+				normal, _ = normal.TryNormalize()
+
+				pos := Vector{float64(x-bounds.Min.X) + 0.5, float64(y-bounds.Min.Y) + 0.5}.Add(offset)
+
+				e.indices[pos] = len(e.points)
+				e.points = append(e.points, pos)
+				e.normals = append(e.normals, normal)
+			}
+		}
+	}
+
+	return &e
+}
+
+// NumPoints returns the number of points on the outline, that is, the number of pixels the outline consists of.
+func (e *MaskEdgeEmitter) NumPoints() int {
+	return len(e.points)
+}
+
+// Sample returns a random point on the outline, and the outward normal at that point. If the outline is empty,
+// it returns ZeroVector and ZeroVector.
+func (e *MaskEdgeEmitter) Sample(rand *rand.Rand) (Vector, Vector) {
+	if len(e.points) == 0 {
+		return ZeroVector, ZeroVector
+	}
+
+	idx := rand.Intn(len(e.points))
+
+	return e.points[idx], e.normals[idx]
+}
+
+// Normal returns the outward normal at pos. If pos is not a point on the outline, it returns ZeroVector and false.
+func (e *MaskEdgeEmitter) Normal(pos Vector) (Vector, bool) {
+	idx, ok := e.indices[pos]
+	if !ok {
+		return ZeroVector, false
+	}
+
+	return e.normals[idx], true
+}
+
+// PositionOverTime returns a function that can be used as ParticleSystem.EmissionPositionOverTime.
+// Particles will be spawned at random points on the outline.
+func (e *MaskEdgeEmitter) PositionOverTime(rand *rand.Rand) VectorOverTimeFunc {
+	return func(d time.Duration, delta time.Duration) Vector {
+		pos, _ := e.Sample(rand)
+		return pos
+	}
+}
+
+// VelocityOverTime returns a function that can be used as ParticleSystem.InitialVelocityOverTime. Particles will
+// be emitted into the direction of the outward normal at their spawn position, with the given speed. Particles
+// not spawned on the outline will spawn with a velocity of zero. It should be used together with PositionOverTime.
+func (e *MaskEdgeEmitter) VelocityOverTime(speed float64) SpawnVectorOverTimeFunc {
+	return func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+		normal, _ := e.Normal(spawnPos)
+		return normal.Multiply(speed)
+	}
+}
+
+// opaqueFunc returns a function that reports whether the pixel of img at (x,y) has an alpha of at least threshold.
+// Pixels outside of img's bounds are not opaque.
+func opaqueFunc(img image.Image, threshold float64) func(x int, y int) bool {
+	bounds := img.Bounds()
+
+	return func(x int, y int) bool {
+		if !(image.Point{x, y}).In(bounds) {
+			return false
+		}
+
+		_, _, _, a := img.At(x, y).RGBA()
+
+		return float64(a)/0xffff >= threshold
+	}
+}
@@ -0,0 +1,68 @@
+package twodeeparticles
+
+import (
+	"image"
+	"image/color"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestNewMaskEdgeEmitter(t *testing.T) {
+	is := is.New(t)
+
+	img := image.NewAlpha(image.Rect(0, 0, 10, 10))
+	for y := 2; y < 7; y++ {
+		for x := 3; x < 8; x++ {
+			img.SetAlpha(x, y, color.Alpha{0xff})
+		}
+	}
+
+	e := NewMaskEdgeEmitter(img, 0.5, Vector{-5, -5})
+
+	is.Equal(e.NumPoints(), 16)
+
+	rand := rand.New(rand.NewSource(1)) //nolint:gosec // no crypto
+
+	for i := 0; i < 100; i++ {
+		pos, normal := e.Sample(rand)
+
+		is.True(pos.X >= -1.5 && pos.X <= 2.5)
+		is.True(pos.Y >= -2.5 && pos.Y <= 1.5)
+		is.True(approxEqual(normal.Magnitude(), 1.0))
+
+		center := Vector{0.5, -0.5}
+		is.True(pos.Add(center.Multiply(-1.0)).Add(normal).Magnitude() > pos.Add(center.Multiply(-1.0)).Magnitude())
+	}
+}
+
+func TestMaskEdgeEmitter_VelocityOverTime(t *testing.T) {
+	is := is.New(t)
+
+	img := image.NewAlpha(image.Rect(0, 0, 10, 10))
+	img.SetAlpha(5, 5, color.Alpha{0xff})
+	img.SetAlpha(6, 5, color.Alpha{0xff})
+
+	e := NewMaskEdgeEmitter(img, 0.5, ZeroVector)
+
+	sys := NewSystem()
+	sys.MaxParticles = 10
+	sys.EmissionPositionOverTime = e.PositionOverTime(rand.New(rand.NewSource(1))) //nolint:gosec // no crypto
+	sys.InitialVelocityOverTime = e.VelocityOverTime(10.0)
+	sys.Spawn(10)
+
+	now := time.Now()
+	sys.Update(now)
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		if p.Position().X < 6 {
+			is.True(p.Velocity().X < 0)
+		} else {
+			is.True(p.Velocity().X > 0)
+		}
+
+		is.True(approxEqual(p.Velocity().Magnitude(), 10.0))
+	}, now)
+}
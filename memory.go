@@ -0,0 +1,63 @@
+package twodeeparticles
+
+import "unsafe"
+
+// MemoryStats are estimates of the memory used by a particle system. The estimates only include memory that is held
+// by the system itself, not memory referenced by particles (such as the data returned by DataOverLifetime.)
+type MemoryStats struct {
+	// Particles is the number of particles currently held by the system, including dead particles that have not
+	// been removed yet.
+	Particles int
+
+	// PooledParticles is the number of particles that are kept for reuse.
+	PooledParticles int
+
+	// ParticleBytes is the number of bytes used by particles, including pooled particles, and their timers.
+	ParticleBytes int
+
+	// SpatialIndexBytes is the number of bytes used by the spatial index, which is used by Separation, Fluid, Merge,
+	// and DensityRadius.
+	SpatialIndexBytes int
+
+	// ScratchBytes is the number of bytes used by temporary buffers that are reused between updates.
+	ScratchBytes int
+
+	// SnapshotBytes is the number of bytes a RenderSnapshot of the system's current particles will use.
+	// Snapshots are pooled across all systems, so this memory is not held by the system itself.
+	SnapshotBytes int
+}
+
+// TotalBytes returns the total number of bytes held by the system, not including SnapshotBytes.
+func (s MemoryStats) TotalBytes() int {
+	return s.ParticleBytes + s.SpatialIndexBytes + s.ScratchBytes
+}
+
+// MemoryStats returns estimates of the memory used by the system. This can be used to budget effects on platforms
+// with constrained memory. MemoryStats is relatively cheap, but iterates over all particles, so it should not be
+// called too often.
+func (sys *ParticleSystem) MemoryStats() MemoryStats {
+	const ptrSize = int(unsafe.Sizeof(uintptr(0)))
+
+	stats := MemoryStats{
+		Particles:       len(sys.particles),
+		PooledParticles: len(sys.free),
+	}
+
+	stats.ParticleBytes = (len(sys.particles) + len(sys.free)) * int(unsafe.Sizeof(Particle{}))
+	stats.ParticleBytes += (cap(sys.particles) + cap(sys.free) + cap(sys.respawned)) * ptrSize
+
+	for _, parts := range [][]*Particle{sys.particles, sys.free} {
+		for _, p := range parts {
+			stats.ParticleBytes += cap(p.timers) * int(unsafe.Sizeof(particleTimer{}))
+		}
+	}
+
+	for _, parts := range sys.grid.cells {
+		stats.SpatialIndexBytes += int(unsafe.Sizeof(gridCell{})) + int(unsafe.Sizeof(parts)) + cap(parts)*ptrSize
+	}
+
+	stats.ScratchBytes = cap(sys.fluidAccelerations) * int(unsafe.Sizeof(Vector{}))
+	stats.SnapshotBytes = int(unsafe.Sizeof(RenderSnapshot{})) + len(sys.particles)*int(unsafe.Sizeof(RenderParticle{}))
+
+	return stats
+}
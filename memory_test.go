@@ -0,0 +1,45 @@
+package twodeeparticles
+
+import (
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/matryer/is"
+)
+
+func TestParticleSystem_MemoryStats(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 10
+	sys.DensityRadius = 5.0
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return time.Second
+	}
+
+	stats := sys.MemoryStats()
+	is.Equal(stats.Particles, 0)
+	is.Equal(stats.TotalBytes(), 0)
+
+	sys.Spawn(10)
+
+	now := time.Now()
+	sys.Update(now)
+
+	stats = sys.MemoryStats()
+	is.Equal(stats.Particles, 10)
+	is.Equal(stats.PooledParticles, 0)
+	is.True(stats.ParticleBytes >= 10*int(unsafe.Sizeof(Particle{})))
+	is.True(stats.SpatialIndexBytes > 0)
+	is.Equal(stats.SnapshotBytes, int(unsafe.Sizeof(RenderSnapshot{}))+10*int(unsafe.Sizeof(RenderParticle{})))
+
+	now = now.Add(2 * time.Second)
+	sys.Update(now)
+
+	// dead particles are pooled
+	stats = sys.MemoryStats()
+	is.Equal(stats.Particles, 0)
+	is.Equal(stats.PooledParticles, 10)
+	is.True(stats.ParticleBytes >= 10*int(unsafe.Sizeof(Particle{})))
+}
@@ -0,0 +1,72 @@
+package twodeeparticles
+
+import (
+	"image/color"
+	"math"
+)
+
+// A Merge combines particles that are closer to each other than a radius into a single bigger particle. When two
+// particles merge, the older particle absorbs the younger one, which is killed. The scales and colors of the particles
+// are summed, and their positions and velocities are averaged, weighted by their sizes. This can be used for effects
+// such as raindrops running down a window.
+type Merge struct {
+	// Radius is the distance below which particles merge. If Radius is 0, particles do not merge.
+	Radius float64
+}
+
+// applyMerge merges particles according to Merge.
+func (sys *ParticleSystem) applyMerge() {
+	radius := sys.Merge.Radius
+	if radius <= 0 {
+		return
+	}
+
+	sys.buildGrid(radius)
+
+	for _, p := range sys.particles {
+		if !p.isAlive {
+			continue
+		}
+
+		sys.forEachNear(p.position, radius, func(other *Particle, diff Vector, dist float64) {
+			if other.id <= p.id || !other.isAlive {
+				return
+			}
+
+			p.absorb(other, diff)
+			other.Kill()
+		})
+	}
+}
+
+// absorb merges other into p. diff is the vector from p to other.
+func (p *Particle) absorb(other *Particle, diff Vector) {
+	w1 := math.Abs(p.scale.X * p.scale.Y)
+	w2 := math.Abs(other.scale.X * other.scale.Y)
+
+	f := 0.5
+	if w1+w2 > 0 {
+		f = w2 / (w1 + w2)
+	}
+
+	p.position = p.system.wrapBounds().wrap(p.position.Add(diff.Multiply(f)))
+	p.velocity = p.velocity.Multiply(1.0 - f).Add(other.velocity.Multiply(f))
+	p.scale = p.scale.Add(other.scale)
+	p.color = addColors(p.color, other.color)
+}
+
+// addColors returns the sum of c1 and c2, clamped to the maximum value of each channel.
+func addColors(c1 color.Color, c2 color.Color) color.Color {
+	r1, g1, b1, a1 := c1.RGBA()
+	r2, g2, b2, a2 := c2.RGBA()
+
+	add := func(v1 uint32, v2 uint32) uint16 {
+		if v1+v2 > 0xffff {
+			return 0xffff
+		}
+
+		return uint16(v1 + v2)
+	}
+
+	return color.RGBA64{add(r1, r2), add(g1, g2), add(b1, b2), add(a1, a2)}
+}
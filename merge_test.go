@@ -0,0 +1,70 @@
+package twodeeparticles
+
+import (
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestParticleSystem_Update_Merge(t *testing.T) {
+	is := is.New(t)
+
+	positions := []Vector{{0, 0}, {3, 0}, {20, 0}}
+	velocities := []Vector{{0, 0}, {0, 4}, {0, 0}}
+
+	sys := NewSystem()
+	sys.MaxParticles = len(positions)
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return InfiniteLifetime
+	}
+	sys.Merge = Merge{Radius: 4.0}
+
+	idx := 0
+	sys.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) Vector {
+		return positions[idx]
+	}
+	sys.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+		v := velocities[idx]
+		idx++
+
+		return v
+	}
+
+	sys.UpdateFunc = func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		if p.Age() == 0 {
+			p.color = color.RGBA{0x00, 0x00, 0x40, 0x40}
+		}
+	}
+
+	deaths := 0
+	sys.DeathFunc = func(p *Particle) {
+		deaths++
+	}
+
+	sys.Spawn(len(positions))
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(100 * time.Millisecond)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 2)
+	is.Equal(deaths, 1)
+
+	var parts []*Particle
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		parts = append(parts, p)
+	}, now)
+
+	is.Equal(parts[0].ID(), uint64(1))
+	is.True(approxEqualVector(parts[0].Velocity(), Vector{0, 2}))
+	is.True(approxEqualVector(parts[0].Position(), Vector{1.5, 0.2}))
+	is.Equal(parts[0].Scale(), Vector{2, 2})
+	is.Equal(parts[0].Color(), color.Color(color.RGBA64{0x0000, 0x0000, 0x8080, 0x8080}))
+
+	is.Equal(parts[1].Scale(), OneVector)
+}
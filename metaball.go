@@ -0,0 +1,266 @@
+package twodeeparticles
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+)
+
+// A Metaballs renders particles as metaballs, in software. Each particle contributes to a scalar field that falls off
+// smoothly with the distance from the particle. Where the field exceeds a threshold, particles merge into blobs, which
+// makes them look like liquid (for example, slime or water drops.) The rendered image can be uploaded to the GPU
+// to draw it, for example using ebiten.NewImageFromImage.
+//
+// The zero value is ready to use, but Radius and Threshold should be set. A Metaballs reuses internal buffers between
+// renders, and must not be used concurrently.
+type Metaballs struct {
+	// Radius is the radius of influence of a particle with a scale of 1.0, in pixels. It is multiplied by
+	// the particle's scale.
+	Radius float64
+
+	// Threshold is the field value at which blobs are filled. A single particle contributes 1.0 at its center,
+	// falling off to 0.0 at its radius.
+	Threshold float64
+
+	field  []float64
+	colors []float64
+}
+
+// Render renders the particles in snap into dst. origin is the position of the particle system's origin in dst.
+// Pixels inside blobs are set to the average color of the particles contributing to them, weighted by their
+// contributions. All other pixels are set to transparent.
+func (m *Metaballs) Render(dst draw.Image, snap *RenderSnapshot, origin Vector) {
+	bounds := dst.Bounds()
+	m.computeField(bounds, snap, origin)
+
+	w := bounds.Dx()
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			idx := (y-bounds.Min.Y)*w + x - bounds.Min.X
+
+			f := m.field[idx]
+			if f <= 0 || f < m.Threshold {
+				dst.Set(x, y, color.Transparent)
+				continue
+			}
+
+			c := m.colors[idx*4 : idx*4+4]
+
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(math.Min(c[0]/f, 0xffff)),
+				G: uint16(math.Min(c[1]/f, 0xffff)),
+				B: uint16(math.Min(c[2]/f, 0xffff)),
+				A: uint16(math.Min(c[3]/f, 0xffff)),
+			})
+		}
+	}
+}
+
+// computeField computes the field value and weighted color sums for each pixel in bounds.
+func (m *Metaballs) computeField(bounds image.Rectangle, snap *RenderSnapshot, origin Vector) {
+	w := bounds.Dx()
+	h := bounds.Dy()
+
+	m.field = resizeFloats(m.field, w*h)
+	m.colors = resizeFloats(m.colors, w*h*4)
+
+	for _, p := range snap.Particles {
+		r := m.Radius * math.Max(math.Abs(p.Scale.X), math.Abs(p.Scale.Y))
+		if r <= 0 {
+			continue
+		}
+
+		cr, cg, cb, ca := p.Color.RGBA()
+
+		// particle position in image coordinates
+		pos := p.Position.Add(origin)
+
+		minX := int(math.Max(math.Floor(pos.X-r), float64(bounds.Min.X)))
+		maxX := int(math.Min(math.Ceil(pos.X+r), float64(bounds.Max.X-1)))
+		minY := int(math.Max(math.Floor(pos.Y-r), float64(bounds.Min.Y)))
+		maxY := int(math.Min(math.Ceil(pos.Y+r), float64(bounds.Max.Y-1)))
+
+		for y := minY; y <= maxY; y++ {
+			for x := minX; x <= maxX; x++ {
+				f := metaballFalloff(Vector{float64(x) + 0.5 - pos.X, float64(y) + 0.5 - pos.Y}.Magnitude() / r)
+				if f <= 0 {
+					continue
+				}
+
+				idx := (y-bounds.Min.Y)*w + x - bounds.Min.X
+
+				m.field[idx] += f
+				m.colors[idx*4] += float64(cr) * f
+				m.colors[idx*4+1] += float64(cg) * f
+				m.colors[idx*4+2] += float64(cb) * f
+				m.colors[idx*4+3] += float64(ca) * f
+			}
+		}
+	}
+}
+
+// metaballFalloff returns the field contribution of a particle at normalized distance d, which is 1.0 at d=0.0
+// and falls off smoothly to 0.0 at d=1.0.
+func metaballFalloff(d float64) float64 {
+	if d >= 1.0 {
+		return 0.0
+	}
+
+	f := 1.0 - d*d
+
+	return f * f
+}
+
+// resizeFloats returns s with length num and all elements set to zero, reusing its backing array if possible.
+func resizeFloats(s []float64, num int) []float64 {
+	if cap(s) < num {
+		return make([]float64, num)
+	}
+
+	s = s[:num]
+	for idx := range s {
+		s[idx] = 0.0
+	}
+
+	return s
+}
+
+// metaballEdge identifies an edge between two adjacent pixels in the field grid. Horizontal edges connect pixels (x,y)
+// and (x+1,y), vertical edges connect pixels (x,y) and (x,y+1).
+type metaballEdge struct {
+	x, y     int
+	vertical bool
+}
+
+// Outlines returns the outlines of the blobs formed by the particles in snap, as they would be rendered into an image
+// with the given bounds by Render. origin is the position of the particle system's origin in the image. The outlines
+// are extracted using marching squares, and are returned as closed polygons in the particle system's coordinate space.
+// The last point of a polygon is connected to its first point, but is not repeated. Blobs with holes produce separate
+// polygons for their holes.
+//
+// Outlines can be used for gameplay purposes (for example, to check if something touches a liquid), or to draw the
+// blobs as vector shapes.
+func (m *Metaballs) Outlines(snap *RenderSnapshot, bounds image.Rectangle, origin Vector) [][]Vector {
+	m.computeField(bounds, snap, origin)
+
+	w := bounds.Dx()
+	h := bounds.Dy()
+
+	value := func(x int, y int) float64 {
+		if x < 0 || y < 0 || x >= w || y >= h {
+			return 0.0
+		}
+
+		return m.field[y*w+x]
+	}
+
+	inside := func(f float64) bool {
+		return f > 0 && f >= m.Threshold
+	}
+
+	var starts []metaballEdge
+
+	neighbors := map[metaballEdge][]metaballEdge{}
+
+	connect := func(a metaballEdge, b metaballEdge) {
+		if len(neighbors[a]) == 0 {
+			starts = append(starts, a)
+		}
+
+		neighbors[a] = append(neighbors[a], b)
+		neighbors[b] = append(neighbors[b], a)
+	}
+
+	// the field outside of bounds is treated as 0, so that all outlines are closed
+	for y := -1; y < h; y++ {
+		for x := -1; x < w; x++ {
+			f := [4]float64{value(x, y), value(x+1, y), value(x+1, y+1), value(x, y+1)}
+
+			top := metaballEdge{x, y, false}
+			right := metaballEdge{x + 1, y, true}
+			bottom := metaballEdge{x, y + 1, false}
+			left := metaballEdge{x, y, true}
+			edges := [4]metaballEdge{top, right, bottom, left}
+
+			var crossed []metaballEdge
+
+			for idx := range f {
+				if inside(f[idx]) != inside(f[(idx+1)%4]) {
+					crossed = append(crossed, edges[idx])
+				}
+			}
+
+			switch len(crossed) {
+			case 2:
+				connect(crossed[0], crossed[1])
+
+			case 4:
+				// saddle: decide using the center value whether the inside corners are connected
+				centerInside := inside((f[0] + f[1] + f[2] + f[3]) / 4.0)
+
+				if centerInside == inside(f[0]) {
+					connect(top, right)
+					connect(bottom, left)
+				} else {
+					connect(left, top)
+					connect(right, bottom)
+				}
+			}
+		}
+	}
+
+	point := func(e metaballEdge) Vector {
+		f0 := value(e.x, e.y)
+		x1, y1 := e.x+1, e.y
+		if e.vertical {
+			x1, y1 = e.x, e.y+1
+		}
+		f1 := value(x1, y1)
+
+		t := (m.Threshold - f0) / (f1 - f0)
+		if math.IsNaN(t) || math.IsInf(t, 0) {
+			t = 0.5
+		}
+
+		t = math.Max(0.0, math.Min(t, 1.0))
+
+		a := Vector{float64(bounds.Min.X+e.x) + 0.5, float64(bounds.Min.Y+e.y) + 0.5}
+		b := Vector{float64(bounds.Min.X+x1) + 0.5, float64(bounds.Min.Y+y1) + 0.5}
+
+		return a.Multiply(1.0 - t).Add(b.Multiply(t)).Add(origin.Multiply(-1.0))
+	}
+
+	visited := map[metaballEdge]bool{}
+
+	var outlines [][]Vector
+
+	for _, start := range starts {
+		if visited[start] {
+			continue
+		}
+
+		var outline []Vector
+
+		prev := start
+		e := start
+
+		for !visited[e] {
+			visited[e] = true
+			outline = append(outline, point(e))
+
+			next := neighbors[e][0]
+			if next == prev && len(neighbors[e]) > 1 {
+				next = neighbors[e][1]
+			}
+
+			prev = e
+			e = next
+		}
+
+		outlines = append(outlines, outline)
+	}
+
+	return outlines
+}
@@ -0,0 +1,112 @@
+package twodeeparticles
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestMetaballs_Render(t *testing.T) {
+	is := is.New(t)
+
+	positions := []Vector{{-5, 0}, {5, 0}, {0, 30}}
+
+	sys := NewSystem()
+	sys.MaxParticles = len(positions)
+	sys.ColorOverLifetime = func(p *Particle, t NormalizedDuration, delta time.Duration) color.Color {
+		return color.RGBA{0xff, 0x00, 0x00, 0xff}
+	}
+
+	idx := 0
+	sys.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) Vector {
+		pos := positions[idx]
+		idx++
+
+		return pos
+	}
+
+	sys.Spawn(len(positions))
+
+	now := time.Now()
+	sys.Update(now)
+
+	snap := sys.SnapshotForRender(now)
+	defer snap.Release()
+
+	img := image.NewRGBA(image.Rect(0, 0, 100, 100))
+	m := Metaballs{Radius: 8.0, Threshold: 0.5}
+	m.Render(img, snap, Vector{50, 50})
+
+	// between the two close particles, the fields add up
+	is.Equal(img.At(50, 50), color.RGBA{0xff, 0x00, 0x00, 0xff})
+
+	// at the lone particle's center
+	is.Equal(img.At(50, 80), color.RGBA{0xff, 0x00, 0x00, 0xff})
+
+	// between the lone particle and the others
+	is.Equal(img.At(50, 65), color.RGBA{})
+
+	// outside of the lone particle's blob, but within its radius
+	is.Equal(img.At(56, 80), color.RGBA{})
+
+	m.Render(img, snap, Vector{0, 0})
+	is.Equal(img.At(50, 50), color.RGBA{})
+}
+
+func TestMetaballs_Outlines(t *testing.T) {
+	is := is.New(t)
+
+	positions := []Vector{{-5, 0}, {5, 0}, {0, 30}}
+
+	sys := NewSystem()
+	sys.MaxParticles = len(positions)
+
+	idx := 0
+	sys.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) Vector {
+		pos := positions[idx]
+		idx++
+
+		return pos
+	}
+
+	sys.Spawn(len(positions))
+
+	now := time.Now()
+	sys.Update(now)
+
+	snap := sys.SnapshotForRender(now)
+	defer snap.Release()
+
+	m := Metaballs{Radius: 8.0, Threshold: 0.5}
+	outlines := m.Outlines(snap, image.Rect(0, 0, 100, 100), Vector{50, 50})
+
+	is.Equal(len(outlines), 2)
+
+	// the lone particle's outline is a circle around it
+	var lone []Vector
+
+	for _, o := range outlines {
+		if o[0].Y > 15 {
+			lone = o
+		}
+	}
+
+	is.True(len(lone) > 8)
+
+	for _, v := range lone {
+		r := Vector{v.X - positions[2].X, v.Y - positions[2].Y}.Magnitude()
+		// metaballFalloff(d) = 0.5 at d = sqrt(1 - sqrt(0.5))
+		is.True(math.Abs(r-8.0*math.Sqrt(1.0-math.Sqrt(0.5))) < 0.5)
+	}
+
+	// blobs cut off by the image bounds are closed along the bounds
+	outlines = m.Outlines(snap, image.Rect(0, 0, 50, 100), Vector{50, 50})
+	is.Equal(len(outlines), 2)
+
+	outlines = m.Outlines(snap, image.Rect(0, 0, 10, 10), Vector{50, 50})
+	is.Equal(len(outlines), 0)
+}
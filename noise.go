@@ -0,0 +1,118 @@
+package twodeeparticles
+
+import "math"
+
+// A Noise generates smooth value noise. Noise is deterministic: the same parameters always produce the same values,
+// so effects can refer to noise by its parameters alone.
+type Noise struct {
+	// Seed determines the random values of the noise.
+	Seed uint64
+
+	// Frequency is the number of random values per unit. If Frequency is 0, 1.0 is used.
+	Frequency float64
+
+	// Octaves is the number of layers of noise that are added, each with double the frequency and half
+	// the amplitude of the previous one. More octaves add finer detail. If Octaves is 0, 1 is used.
+	Octaves int
+}
+
+// At returns one-dimensional noise at x, in the range [0.0,1.0].
+func (n Noise) At(x float64) float64 {
+	return n.fractal(func(f float64, seed uint64) float64 {
+		return valueNoise(x*f, seed)
+	})
+}
+
+// At2D returns two-dimensional noise at (x,y), in the range [0.0,1.0].
+func (n Noise) At2D(x float64, y float64) float64 {
+	return n.fractal(func(f float64, seed uint64) float64 {
+		return valueNoise2D(x*f, y*f, seed)
+	})
+}
+
+// fractal sums the octaves of noise returned by noise for frequency f and seed, and normalizes the result.
+func (n Noise) fractal(noise func(f float64, seed uint64) float64) float64 {
+	f := n.Frequency
+	if f == 0 {
+		f = 1.0
+	}
+
+	octaves := n.Octaves
+	if octaves <= 0 {
+		octaves = 1
+	}
+
+	sum := 0.0
+	total := 0.0
+	amp := 1.0
+
+	for o := 0; o < octaves; o++ {
+		sum += noise(f, n.Seed+uint64(o)) * amp
+		total += amp
+
+		f *= 2.0
+		amp *= 0.5
+	}
+
+	return sum / total
+}
+
+// valueNoise returns one-dimensional value noise at x, in the range [0.0,1.0]. The noise is smooth, and has
+// one random value per integer lattice point, which is determined by seed.
+func valueNoise(x float64, seed uint64) float64 {
+	x0 := math.Floor(x)
+	f := smoothstep(x - x0)
+
+	i := int64(x0)
+	v0 := latticeValue(i, seed)
+	v1 := latticeValue(i+1, seed)
+
+	return v0 + (v1-v0)*f
+}
+
+// valueNoise2D returns two-dimensional value noise at (x,y), in the range [0.0,1.0].
+func valueNoise2D(x float64, y float64, seed uint64) float64 {
+	x0 := math.Floor(x)
+	y0 := math.Floor(y)
+	fx := smoothstep(x - x0)
+	fy := smoothstep(y - y0)
+
+	i := int64(x0)
+	j := int64(y0)
+	v00 := latticeValue2D(i, j, seed)
+	v10 := latticeValue2D(i+1, j, seed)
+	v01 := latticeValue2D(i, j+1, seed)
+	v11 := latticeValue2D(i+1, j+1, seed)
+
+	v0 := v00 + (v10-v00)*fx
+	v1 := v01 + (v11-v01)*fx
+
+	return v0 + (v1-v0)*fy
+}
+
+func smoothstep(f float64) float64 {
+	return f * f * (3.0 - 2.0*f)
+}
+
+// latticeValue returns a random value in the range [0.0,1.0) for lattice point i, which is determined by seed.
+func latticeValue(i int64, seed uint64) float64 {
+	return hashFloat(seed + uint64(i)*0x9e3779b97f4a7c15)
+}
+
+// latticeValue2D returns a random value in the range [0.0,1.0) for lattice point (i,j), which is determined by seed.
+func latticeValue2D(i int64, j int64, seed uint64) float64 {
+	return hashFloat(seed + uint64(i)*0x9e3779b97f4a7c15 + uint64(j)*0xc2b2ae3d27d4eb4f)
+}
+
+// hashUint64 hashes z to a random value, using the splitmix64 finalizer.
+func hashUint64(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+
+	return z ^ (z >> 31)
+}
+
+// hashFloat hashes z to a random value in the range [0.0,1.0), using the splitmix64 finalizer.
+func hashFloat(z uint64) float64 {
+	return float64(hashUint64(z)>>11) / (1 << 53)
+}
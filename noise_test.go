@@ -0,0 +1,45 @@
+package twodeeparticles
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestNoise_At(t *testing.T) {
+	is := is.New(t)
+
+	n := Noise{Seed: 1}
+
+	is.Equal(n.At(3.0), latticeValue(3, 1))
+	is.True(n.At(3.0) != Noise{Seed: 2}.At(3.0))
+	is.Equal(n.At(1.234), n.At(1.234))
+
+	for _, n := range []Noise{{Seed: 1}, {Seed: 1, Frequency: 4.0, Octaves: 3}} {
+		prev := n.At(0.0)
+
+		for x := 0.01; x < 10.0; x += 0.01 {
+			v := n.At(x)
+			is.True(v >= 0.0 && v <= 1.0)
+			is.True(v-prev < 0.2 && prev-v < 0.2)
+
+			prev = v
+		}
+	}
+}
+
+func TestNoise_At2D(t *testing.T) {
+	is := is.New(t)
+
+	n := Noise{Seed: 1, Octaves: 2}
+
+	is.Equal(Noise{Seed: 1}.At2D(2.0, 5.0), latticeValue2D(2, 5, 1))
+	is.True(n.At2D(2.5, 5.5) != n.At2D(5.5, 2.5))
+
+	for y := 0.0; y < 5.0; y += 0.1 {
+		for x := 0.0; x < 5.0; x += 0.1 {
+			v := n.At2D(x, y)
+			is.True(v >= 0.0 && v <= 1.0)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package twodeeparticles
+
+import "math"
+
+// An Oscillation adds a sine wave to the positions or velocities of particles, for example to make leaves or snowflakes
+// wobble while they fall.
+type Oscillation struct {
+	// Target is the property of particles that the oscillation is added to.
+	Target OscillationTarget
+
+	// Axis is the direction of the oscillation. Its length is ignored.
+	Axis Vector
+
+	// Amplitude is the amplitude of the oscillation, in arbitrary units for OscillatePosition, or in arbitrary
+	// units per second for OscillateVelocity.
+	Amplitude float64
+
+	// Frequency is the frequency of the oscillation, in Hertz.
+	Frequency float64
+
+	// RandomPhase shifts the oscillation by a random amount for each particle, so that particles do not oscillate
+	// in unison.
+	RandomPhase bool
+}
+
+// OscillationTarget is the property of particles that an Oscillation is added to.
+type OscillationTarget int
+
+const (
+	// OscillatePosition adds the oscillation to particles' positions, without changing their velocities.
+	OscillatePosition OscillationTarget = iota
+
+	// OscillateVelocity adds the oscillation to particles' velocities. The oscillation is added on top of the velocity
+	// computed in each update, and is removed again before the next update, so it works with VelocityOverLifetime
+	// regardless of whether it takes particles' current velocities into account.
+	OscillateVelocity
+)
+
+// offset returns the value of o for p at age sec, in seconds.
+func (o Oscillation) offset(p *Particle, sec float64) Vector {
+	axis, ok := o.Axis.TryNormalize()
+	if !ok {
+		return ZeroVector
+	}
+
+	phase := 0.0
+	if o.RandomPhase {
+		phase = p.Random(randomOscillationPhase)
+	}
+
+	return axis.Multiply(o.Amplitude * math.Sin(2.0*math.Pi*(o.Frequency*sec+phase)))
+}
+
+// change returns the change of o's value for p from its previous update to its current one.
+func (o Oscillation) change(p *Particle, delta float64) Vector {
+	sec := p.age.Seconds()
+	prev := math.Max(0.0, sec-delta)
+
+	return o.offset(p, sec).Add(o.offset(p, prev).Multiply(-1.0))
+}
@@ -0,0 +1,141 @@
+package twodeeparticles
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestOscillation_Position(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 1
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return InfiniteLifetime
+	}
+	sys.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+		return Vector{0, 10}
+	}
+	sys.Oscillations = []Oscillation{
+		{Axis: Vector{2, 0}, Amplitude: 5, Frequency: 1},
+	}
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	expected := []Vector{{5, 2.5}, {0, 5}, {-5, 7.5}, {0, 10}}
+
+	for _, e := range expected {
+		now = now.Add(250 * time.Millisecond)
+		sys.Update(now)
+
+		sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+			is.True(approxEqualVector(p.Position(), e))
+			is.Equal(p.Velocity(), Vector{0, 10})
+		}, now)
+	}
+}
+
+func TestOscillation_Velocity(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 1
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return InfiniteLifetime
+	}
+	sys.Oscillations = []Oscillation{
+		{Target: OscillateVelocity, Axis: Vector{0, 1}, Amplitude: 5, Frequency: 0.5, RandomPhase: true},
+	}
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	var phase float64
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		phase = p.Random(randomOscillationPhase)
+	}, now)
+
+	for i := 1; i <= 10; i++ {
+		now = now.Add(100 * time.Millisecond)
+		sys.Update(now)
+
+		sec := float64(i) * 0.1
+		v := 5.0 * math.Sin(2.0*math.Pi*(0.5*sec+phase))
+
+		sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+			is.True(approxEqualVector(p.Velocity(), Vector{0, v}))
+		}, now)
+	}
+}
+
+func TestOscillation_Velocity_VelocityOverLifetime(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 1
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return InfiniteLifetime
+	}
+	sys.VelocityOverLifetime = func(p *Particle, t NormalizedDuration, delta time.Duration) Vector {
+		return Vector{0, 10}
+	}
+	sys.Oscillations = []Oscillation{
+		{Target: OscillateVelocity, Axis: Vector{1, 0}, Amplitude: 5, Frequency: 1},
+	}
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	expected := []Vector{{5, 10}, {0, 10}, {-5, 10}, {0, 10}}
+
+	for _, e := range expected {
+		now = now.Add(250 * time.Millisecond)
+		sys.Update(now)
+
+		sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+			is.True(approxEqualVector(p.Velocity(), e))
+		}, now)
+	}
+}
+
+func TestOscillation_Velocity_Persistent(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 1
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return InfiniteLifetime
+	}
+	sys.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+		return Vector{0, 10}
+	}
+	sys.VelocityOverLifetime = func(p *Particle, t NormalizedDuration, delta time.Duration) Vector {
+		return p.Velocity()
+	}
+	sys.Oscillations = []Oscillation{
+		{Target: OscillateVelocity, Axis: Vector{1, 0}, Amplitude: 5, Frequency: 1},
+	}
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	expected := []Vector{{5, 10}, {0, 10}, {-5, 10}, {0, 10}}
+
+	for _, e := range expected {
+		now = now.Add(250 * time.Millisecond)
+		sys.Update(now)
+
+		sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+			is.True(approxEqualVector(p.Velocity(), e))
+		}, now)
+	}
+}
@@ -0,0 +1,48 @@
+package twodeeparticles
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// A Palette is a list of colors that can be sampled randomly.
+type Palette struct {
+	// Colors are the colors of the palette.
+	Colors []color.Color
+
+	// Weights are the relative probabilities of the colors being sampled. If Weights is nil, all colors
+	// are equally likely to be sampled. Otherwise, Weights must have the same length as Colors.
+	Weights []float64
+}
+
+// Sample returns a random color from pal. If pal does not contain any colors, it returns color.White.
+func (pal Palette) Sample(rand *rand.Rand) color.Color {
+	if len(pal.Colors) == 0 {
+		return color.White
+	}
+
+	return WeightedChoice[color.Color]{Items: pal.Colors, Weights: pal.Weights}.Sample(rand)
+}
+
+// StartColorFromPalette returns a function that can be used as ParticleSystem.ColorOverLifetime. A particle will
+// be assigned a random color from pal when it is spawned, and will keep that color during its lifetime.
+func StartColorFromPalette(pal Palette, rand *rand.Rand) ParticleColorOverNormalizedTimeFunc {
+	return func(p *Particle, t NormalizedDuration, delta time.Duration) color.Color {
+		if p.updated {
+			return p.color
+		}
+
+		return pal.Sample(rand)
+	}
+}
+
+// fadeColor returns c with its opacity multiplied by f, in the range [0.0,1.0]. Since colors are alpha-premultiplied,
+// all channels are multiplied.
+func fadeColor(c color.Color, f float64) color.Color {
+	f = math.Max(0.0, math.Min(f, 1.0))
+	r, g, b, a := c.RGBA()
+
+	return color.RGBA64{uint16(float64(r) * f), uint16(float64(g) * f), uint16(float64(b) * f), uint16(float64(a) * f)}
+}
@@ -0,0 +1,73 @@
+package twodeeparticles
+
+import (
+	"image/color"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestPalette_Sample(t *testing.T) {
+	is := is.New(t)
+
+	rand := rand.New(rand.NewSource(1)) //nolint:gosec // no crypto
+
+	is.Equal(Palette{}.Sample(rand), color.White)
+
+	red := color.RGBA{0xff, 0x00, 0x00, 0xff}
+	green := color.RGBA{0x00, 0xff, 0x00, 0xff}
+	pal := Palette{
+		Colors:  []color.Color{red, green},
+		Weights: []float64{3.0, 1.0},
+	}
+
+	counts := map[color.Color]int{}
+	for i := 0; i < 1000; i++ {
+		counts[pal.Sample(rand)]++
+	}
+
+	is.Equal(len(counts), 2)
+	is.True(counts[red] > counts[green]*2)
+
+	pal.Weights = []float64{0.0, 1.0}
+	for i := 0; i < 100; i++ {
+		is.Equal(pal.Sample(rand), green)
+	}
+}
+
+func TestStartColorFromPalette(t *testing.T) {
+	is := is.New(t)
+
+	pal := Palette{
+		Colors: []color.Color{
+			color.RGBA{0xff, 0x00, 0x00, 0xff},
+			color.RGBA{0x00, 0xff, 0x00, 0xff},
+			color.RGBA{0x00, 0x00, 0xff, 0xff},
+		},
+	}
+
+	sys := NewSystem()
+	sys.MaxParticles = 20
+	sys.ColorOverLifetime = StartColorFromPalette(pal, rand.New(rand.NewSource(1))) //nolint:gosec // no crypto
+	sys.Spawn(20)
+
+	now := time.Now()
+	sys.Update(now)
+
+	colors := map[*Particle]color.Color{}
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		colors[p] = p.Color()
+	}, now)
+
+	for i := 0; i < 5; i++ {
+		now = now.Add(100 * time.Millisecond)
+		sys.Update(now)
+	}
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.Equal(p.Color(), colors[p])
+	}, now)
+}
@@ -3,9 +3,35 @@ package twodeeparticles
 import (
 	"image/color"
 	"math"
+	"math/rand"
 	"time"
 )
 
+// InfiniteLifetime is a particle lifetime that never ends. A particle with an infinite lifetime only dies when it
+// is killed. Its normalized duration is always 0.
+const InfiniteLifetime = time.Duration(math.MaxInt64)
+
+// Channels used with Particle.Random by helpers of this package.
+const (
+	randomScaleStart = -1 - iota
+	randomScaleEnd
+	randomFlickerPhase
+	randomDutyCyclePhase
+	randomOscillationPhase
+	randomSeparationAngle
+	randomFluidAngle
+	randomBurstStagger
+	randomFakeSpinSpeedX
+	randomFakeSpinSpeedY
+	randomFakeSpinPhaseX
+	randomFakeSpinPhaseY
+	randomFakeSpinDirectionX
+	randomFakeSpinDirectionY
+)
+
+// white is color.White, converted to color.Color once to avoid an allocation for every particle spawned.
+var white color.Color = color.White
+
 // A Particle is a part of a particle system.
 type Particle struct {
 	system         *ParticleSystem
@@ -13,20 +39,39 @@ type Particle struct {
 	birthTime      time.Time
 	deathTime      time.Time
 	lastUpdateTime time.Time
+	age            time.Duration
+	id             uint64
+	seed           uint64
+
+	isAlive         bool
+	updated         bool
+	visible         bool
+	data            any
+	position        Vector
+	velocity        Vector
+	oscillation     Vector
+	scale           Vector
+	pivot           Vector
+	skew            Vector
+	angle           float64
+	angularVelocity float64
+	color           color.Color
+	distance        float64
+	density         float64
+	pressure        float64
+	timers          []particleTimer
 
-	isAlive  bool
-	data     any
-	position Vector
-	velocity Vector
-	scale    Vector
-	angle    float64
-	color    color.Color
+	spawnPosition Vector
+	startVelocity Vector
+	startScale    Vector
+	startColor    color.Color
 }
 
 func newParticle(sys *ParticleSystem) *Particle {
 	return &Particle{
-		system: sys,
-		color:  color.White,
+		system:  sys,
+		color:   white,
+		visible: true,
 	}
 }
 
@@ -35,6 +80,12 @@ func (p *Particle) System() *ParticleSystem {
 	return p.system
 }
 
+// ID returns p's ID. IDs are assigned in the order particles are spawned (or respawned, see RespawnMode), starting
+// at 1, and are unique within p's system. Particles are always updated in ID order.
+func (p *Particle) ID() uint64 {
+	return p.id
+}
+
 // Data returns the arbitrary data that has been assigned to p (see ParticleSystem.DataOverLifetime.)
 func (p *Particle) Data() any {
 	return p.data
@@ -57,21 +108,109 @@ func (p *Particle) Scale() Vector {
 	return p.scale
 }
 
+// Pivot returns p's current pivot (see ParticleSystem.PivotOverLifetime.)
+func (p *Particle) Pivot() Vector {
+	return p.pivot
+}
+
+// Skew returns p's current skew angles, in radians (see ParticleSystem.SkewOverLifetime.)
+func (p *Particle) Skew() Vector {
+	return p.skew
+}
+
 // Angle returns p's current rotation angle, in radians.
 func (p *Particle) Angle() float64 {
 	return p.angle
 }
 
+// AngularVelocity returns p's current angular velocity, in radians per second.
+func (p *Particle) AngularVelocity() float64 {
+	return p.angularVelocity
+}
+
 // Color returns p's current color.
 func (p *Particle) Color() color.Color {
 	return p.color
 }
 
+// Visible returns whether p should be drawn (see ParticleSystem.VisibilityOverLifetime.)
+func (p *Particle) Visible() bool {
+	return p.visible
+}
+
+// SpawnPosition returns the position that p has been spawned at, relative to its system's origin.
+func (p *Particle) SpawnPosition() Vector {
+	return p.spawnPosition
+}
+
+// StartVelocity returns p's velocity after its first update, that is, its velocity at the start of its lifetime.
+func (p *Particle) StartVelocity() Vector {
+	return p.startVelocity
+}
+
+// StartScale returns p's scale after its first update, that is, its scale at the start of its lifetime.
+func (p *Particle) StartScale() Vector {
+	return p.startScale
+}
+
+// StartColor returns p's color after its first update, that is, its color at the start of its lifetime.
+func (p *Particle) StartColor() color.Color {
+	return p.startColor
+}
+
+// DistanceTravelled returns the length of the path that p has travelled since it has been spawned,
+// in arbitrary units (for example, in pixels.)
+func (p *Particle) DistanceTravelled() float64 {
+	return p.distance
+}
+
+// Density returns the local density of particles around p, as of the current Update. Each particle within
+// ParticleSystem.DensityRadius around p, including p itself, contributes to the density, with a weight that decreases
+// linearly from 1.0 at p's position to 0.0 at DensityRadius. If DensityRadius is 0, the density is always 0.
+func (p *Particle) Density() float64 {
+	return p.density
+}
+
+// Age returns how long p has been alive, as of its current or most recent update. Unlike p's normalized duration,
+// the age is also meaningful for particles with an infinite lifetime.
+func (p *Particle) Age() time.Duration {
+	return p.age
+}
+
 // Lifetime returns p's maximum lifetime.
 func (p *Particle) Lifetime() time.Duration {
 	return p.lifetime
 }
 
+// SetLifetime changes p's maximum lifetime to l, measured from the time p has been spawned. Since p's normalized
+// duration depends on its lifetime, the normalized duration passed to callbacks will change accordingly.
+// If l has already been exceeded, p will die on the next update.
+func (p *Particle) SetLifetime(l time.Duration) {
+	p.lifetime = l
+	p.deathTime = p.birthTime.Add(l)
+}
+
+// JitterLifetime randomly changes p's maximum lifetime by up to f times its current lifetime, in either direction.
+// For example, if f is 0.1, p's lifetime will be changed by up to ±10%. This can be used to prevent particles
+// spawned at the same time from all dying at the same time. An infinite lifetime is not changed.
+func (p *Particle) JitterLifetime(f float64, rand *rand.Rand) {
+	if p.lifetime == InfiniteLifetime {
+		return
+	}
+
+	p.SetLifetime(time.Duration(float64(p.lifetime) * math.Max(0.0, 1.0+(rand.Float64()*2.0-1.0)*f)))
+}
+
+// Random returns a random value in the range [0.0,1.0) that is fixed for p and channel n. Calling Random with the same n
+// repeatedly returns the same value, but different particles and different channels return different values. This
+// allows to randomize properties of p once at spawn time without having to attach data to p. When p is respawned
+// (see RespawnMode), the values change. If ParticleSystem.Deterministic is set, the values only depend on p's ID.
+//
+// Helpers of this package such as ScaleFromTo use negative channels, so callers should use channels 0 and up.
+func (p *Particle) Random(n int) float64 {
+	return hashFloat(p.seed + uint64(n)*0x9e3779b97f4a7c15)
+}
+
 // Kill kills p, even if p's lifetime has not yet been exceeded.
 func (p *Particle) Kill() {
 	p.isAlive = false
@@ -82,26 +221,70 @@ func (p *Particle) duration(now time.Time) time.Duration {
 }
 
 func (p *Particle) alive(now time.Time) bool {
-	return p.isAlive && p.deathTime.After(now)
+	return p.isAlive && (p.lifetime == InfiniteLifetime || p.deathTime.After(now))
+}
+
+func (p *Particle) normalizedDuration(now time.Time) NormalizedDuration {
+	if p.lifetime == InfiniteLifetime {
+		return 0.0
+	}
+
+	if p.lifetime <= 0 {
+		return 1.0
+	}
+
+	return NormalizedDuration(p.duration(now).Seconds() / p.lifetime.Seconds()).clamp()
 }
 
 func (p *Particle) reset() {
+	p.system.lastID++
+	p.id = p.system.lastID
+
+	p.seed = p.system.particleSeed(p)
 	p.isAlive = true
+	p.updated = false
+	p.visible = true
+	p.age = 0
 	p.data = nil
 	p.position = ZeroVector
 	p.velocity = ZeroVector
+	p.oscillation = ZeroVector
 	p.scale = OneVector
-	p.color = color.White
+	p.pivot = ZeroVector
+	p.skew = ZeroVector
+	p.angle = 0.0
+	p.angularVelocity = 0.0
+	p.color = white
+	p.distance = 0.0
+	p.density = 0.0
+	p.pressure = 0.0
+
+	for idx := range p.timers {
+		p.timers[idx] = particleTimer{}
+	}
+
+	p.timers = p.timers[:0]
 }
 
 func (p *Particle) update(now time.Time) {
+	if !p.updated {
+		p.spawnPosition = p.position
+	}
+
 	defer func() {
+		if !p.updated {
+			p.startVelocity = p.velocity
+			p.startScale = p.scale
+			p.startColor = p.color
+		}
+
 		p.lastUpdateTime = now
+		p.updated = true
 	}()
 
-	d := p.duration(now)
+	p.age = p.duration(now)
 	delta := now.Sub(p.lastUpdateTime)
-	t := NormalizedDuration(d.Seconds() / p.lifetime.Seconds())
+	t := p.normalizedDuration(now)
 
 	if p.system.UpdateFunc != nil {
 		p.system.UpdateFunc(p, t, delta)
@@ -111,22 +294,78 @@ func (p *Particle) update(now time.Time) {
 		p.data = p.system.DataOverLifetime(p.data, t, delta)
 	}
 
+	// remove the previous update's velocity oscillation, so that it is not carried over
+	p.velocity = p.velocity.Add(p.oscillation.Multiply(-1.0))
+	p.oscillation = ZeroVector
+
 	if p.system.VelocityOverLifetime != nil {
 		p.velocity = p.system.VelocityOverLifetime(p, t, delta)
+	} else if p.system.VelocityXOverLifetime != nil || p.system.VelocityYOverLifetime != nil {
+		if p.system.VelocityXOverLifetime != nil {
+			p.velocity.X = p.system.VelocityXOverLifetime(p, t, delta)
+		}
+
+		if p.system.VelocityYOverLifetime != nil {
+			p.velocity.Y = p.system.VelocityYOverLifetime(p, t, delta)
+		}
+	} else if p.system.SpeedOverLifetime != nil || p.system.DirectionOverLifetime != nil {
+		p.velocity = p.speedAndDirectionVelocity(t, delta)
 	}
 
 	sec := delta.Seconds()
-	p.position = p.position.Add(p.velocity.Multiply(sec))
+
+	if p.system.AccelerationOverLifetime != nil {
+		p.velocity = p.velocity.Add(p.system.integrate(p.system.AccelerationOverLifetime(p, t, delta), delta))
+	}
+
+	for _, o := range p.system.Oscillations {
+		if o.Target == OscillateVelocity {
+			p.oscillation = p.oscillation.Add(o.offset(p, p.age.Seconds()))
+		}
+	}
+
+	p.velocity = p.velocity.Add(p.oscillation)
+
+	p.velocity = p.system.quantize(p.velocity)
+
+	step := p.system.integrate(p.velocity, delta)
+
+	for _, o := range p.system.Oscillations {
+		if o.Target == OscillatePosition {
+			step = step.Add(p.system.quantize(o.change(p, sec)))
+		}
+	}
+
+	p.position = p.system.quantize(p.system.wrapBounds().wrap(p.position.Add(step)))
+	p.distance += step.Magnitude()
 
 	if p.system.ScaleOverLifetime != nil {
 		p.scale = p.system.ScaleOverLifetime(p, t, delta)
 	}
 
+	if p.system.PivotOverLifetime != nil {
+		p.pivot = p.system.PivotOverLifetime(p, t, delta)
+	}
+
+	if p.system.SkewOverLifetime != nil {
+		p.skew = p.system.SkewOverLifetime(p, t, delta)
+	}
+
 	if p.system.RotationOverLifetime != nil {
-		p.angle += p.system.RotationOverLifetime(p, t, delta) * delta.Seconds()
-		if p.angle > 2.0*math.Pi {
-			p.angle -= 2.0 * math.Pi
-		} else if p.angle < 0 {
+		p.angularVelocity = p.system.RotationOverLifetime(p, t, delta)
+	}
+
+	if p.system.AngularAccelerationOverLifetime != nil {
+		p.angularVelocity += p.system.AngularAccelerationOverLifetime(p, t, delta) * sec
+	}
+
+	if p.system.SpinDamping > 0 {
+		p.angularVelocity *= math.Exp(-p.system.SpinDamping * sec)
+	}
+
+	if p.angularVelocity != 0 {
+		p.angle = math.Mod(p.angle+p.angularVelocity*sec, 2.0*math.Pi)
+		if p.angle < 0 {
 			p.angle += 2.0 * math.Pi
 		}
 	}
@@ -134,4 +373,29 @@ func (p *Particle) update(now time.Time) {
 	if p.system.ColorOverLifetime != nil {
 		p.color = p.system.ColorOverLifetime(p, t, delta)
 	}
+
+	if p.system.VisibilityOverLifetime != nil {
+		p.visible = p.system.VisibilityOverLifetime(p, t, delta)
+	}
+}
+
+// speedAndDirectionVelocity returns p's velocity according to SpeedOverLifetime and DirectionOverLifetime.
+func (p *Particle) speedAndDirectionVelocity(t NormalizedDuration, delta time.Duration) Vector {
+	speed := p.velocity.Magnitude()
+	if p.system.SpeedOverLifetime != nil {
+		speed = p.system.SpeedOverLifetime(p, t, delta)
+	}
+
+	var dir Vector
+
+	if p.system.DirectionOverLifetime != nil {
+		dir = VectorFromAngle(p.system.DirectionOverLifetime(p, t, delta))
+	} else {
+		var ok bool
+		if dir, ok = p.velocity.TryNormalize(); !ok {
+			return ZeroVector
+		}
+	}
+
+	return dir.Multiply(speed)
 }
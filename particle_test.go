@@ -2,6 +2,8 @@ package twodeeparticles
 
 import (
 	"image/color"
+	"math"
+	"math/rand"
 	"testing"
 	"time"
 
@@ -121,3 +123,421 @@ func TestParticle_Kill(t *testing.T) {
 
 	is.Equal(sys.NumParticles(), 0)
 }
+
+func TestParticle_StartValues(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+
+	sys.MaxParticles = 1
+
+	sys.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) Vector {
+		return Vector{17, 23}
+	}
+
+	sys.VelocityOverLifetime = func(p *Particle, t NormalizedDuration, delta time.Duration) Vector {
+		return Vector{3, 5}.Multiply(float64(t) + 1.0)
+	}
+
+	sys.ScaleOverLifetime = func(p *Particle, t NormalizedDuration, delta time.Duration) Vector {
+		return Vector{7, 11}.Multiply(float64(t) + 1.0)
+	}
+
+	sys.ColorOverLifetime = func(p *Particle, t NormalizedDuration, delta time.Duration) color.Color {
+		return color.RGBA{0x12, 0x23, 0x34, uint8(0x45 + t*0x10)}
+	}
+
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	var part *Particle
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		part = p
+	}, now)
+
+	now = now.Add(500 * time.Millisecond)
+	sys.Update(now)
+
+	is.True(part.Position() != Vector{17, 23})
+	is.True(part.Velocity() != Vector{3, 5})
+	is.Equal(part.SpawnPosition(), Vector{17, 23})
+	is.Equal(part.StartVelocity(), Vector{3, 5})
+	is.Equal(part.StartScale(), Vector{7, 11})
+	is.Equal(part.StartColor(), color.RGBA{0x12, 0x23, 0x34, 0x45})
+}
+
+func TestParticle_DistanceTravelled(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+
+	sys.MaxParticles = 1
+
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 10 * time.Second
+	}
+
+	sys.VelocityOverLifetime = func(p *Particle, t NormalizedDuration, delta time.Duration) Vector {
+		if p.Position().X >= 3 {
+			return Vector{-3, 4}
+		}
+
+		return Vector{3, 4}
+	}
+
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	var part *Particle
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		part = p
+	}, now)
+
+	is.Equal(part.DistanceTravelled(), 0.0)
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	is.Equal(part.Position(), Vector{0, 8})
+	is.Equal(part.DistanceTravelled(), 10.0)
+}
+
+func TestParticle_SetLifetime(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+
+	sys.MaxParticles = 1
+
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	var part *Particle
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		part = p
+	}, now)
+
+	part.SetLifetime(3 * time.Second)
+	is.Equal(part.Lifetime(), 3*time.Second)
+
+	now = now.Add(2 * time.Second)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 1)
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 0)
+}
+
+func TestParticle_JitterLifetime(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+
+	sys.MaxParticles = 100
+
+	sys.Spawn(100)
+
+	now := time.Now()
+	sys.Update(now)
+
+	rand := rand.New(rand.NewSource(1)) //nolint:gosec // no crypto
+	lifetimes := map[time.Duration]struct{}{}
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		p.JitterLifetime(0.1, rand)
+		is.True(p.Lifetime() >= 900*time.Millisecond && p.Lifetime() <= 1100*time.Millisecond)
+
+		lifetimes[p.Lifetime()] = struct{}{}
+	}, now)
+
+	is.True(len(lifetimes) > 1)
+}
+
+func TestParticle_InfiniteLifetime(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+
+	sys.MaxParticles = 1
+
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return InfiniteLifetime
+	}
+
+	var normalized []NormalizedDuration
+	sys.UpdateFunc = func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		normalized = append(normalized, t)
+	}
+
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	var part *Particle
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		part = p
+	}, now)
+
+	for i := 0; i < 3; i++ {
+		now = now.Add(10000 * time.Hour)
+		sys.Update(now)
+	}
+
+	is.Equal(sys.NumParticles(), 1)
+	is.Equal(normalized, []NormalizedDuration{0, 0, 0, 0})
+	is.Equal(part.Age(), 30000*time.Hour)
+
+	part.JitterLifetime(0.5, rand.New(rand.NewSource(1))) //nolint:gosec // no crypto
+	is.Equal(part.Lifetime(), InfiniteLifetime)
+
+	part.Kill()
+
+	now = now.Add(10 * time.Millisecond)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 0)
+}
+
+func TestParticle_Update_SpeedAndDirection(t *testing.T) {
+	is := is.New(t)
+
+	s := NewSystem()
+	s.MaxParticles = 1
+	s.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+		return Vector{3, 4}
+	}
+	s.SpeedOverLifetime = Curve{Keys: []CurveKey{{T: 0.0, Value: 10}, {T: 1.0, Value: 20}}}.OverLifetime()
+	s.Spawn(1)
+
+	now := time.Now()
+	s.Update(now)
+
+	now = now.Add(500 * time.Millisecond)
+	s.Update(now)
+
+	s.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.True(approxEqualVector(p.Velocity(), Vector{9, 12}))
+	}, now)
+
+	s.DirectionOverLifetime = func(p *Particle, t NormalizedDuration, delta time.Duration) float64 {
+		return math.Pi / 2.0
+	}
+
+	now = now.Add(250 * time.Millisecond)
+	s.Update(now)
+
+	s.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.True(approxEqualVector(p.Velocity(), Vector{0, 17.5}))
+	}, now)
+}
+
+func TestParticle_Update_Acceleration(t *testing.T) {
+	is := is.New(t)
+
+	s := NewSystem()
+	s.MaxParticles = 1
+	s.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 10 * time.Second
+	}
+	s.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+		return Vector{1, 0}
+	}
+	s.AccelerationOverLifetime = func(p *Particle, t NormalizedDuration, delta time.Duration) Vector {
+		return Vector{0, 2}
+	}
+	s.Spawn(1)
+
+	now := time.Now()
+	s.Update(now)
+
+	for i := 0; i < 2; i++ {
+		now = now.Add(500 * time.Millisecond)
+		s.Update(now)
+	}
+
+	s.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.Equal(p.Velocity(), Vector{1, 2})
+		is.Equal(p.Position(), Vector{1, 1.5})
+	}, now)
+}
+
+func TestParticle_Random(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 2
+	sys.Spawn(2)
+	sys.Update(time.Now())
+
+	p1 := sys.particles[0]
+	p2 := sys.particles[1]
+
+	is.Equal(p1.Random(0), p1.Random(0))
+	is.True(p1.Random(0) != p1.Random(1))
+	is.True(p1.Random(0) != p2.Random(0))
+
+	for n := -10; n < 100; n++ {
+		r := p1.Random(n)
+		is.True(r >= 0.0 && r < 1.0)
+	}
+}
+
+func TestParticle_Update_VelocityXY(t *testing.T) {
+	is := is.New(t)
+
+	s := NewSystem()
+	s.MaxParticles = 1
+	s.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+		return Vector{3, 4}
+	}
+	s.VelocityXOverLifetime = func(p *Particle, t NormalizedDuration, delta time.Duration) float64 {
+		return 10.0 * float64(t)
+	}
+	s.SpeedOverLifetime = func(p *Particle, t NormalizedDuration, delta time.Duration) float64 {
+		return 100.0
+	}
+	s.Spawn(1)
+
+	now := time.Now()
+	s.Update(now)
+
+	now = now.Add(500 * time.Millisecond)
+	s.Update(now)
+
+	s.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.Equal(p.Velocity(), Vector{5, 4})
+		is.Equal(p.Position(), Vector{2.5, 2})
+	}, now)
+}
+
+func TestParticle_Update_AngularAcceleration(t *testing.T) {
+	is := is.New(t)
+
+	s := NewSystem()
+	s.MaxParticles = 1
+	s.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 10 * time.Second
+	}
+	s.InitialAngularVelocityOverTime = func(d time.Duration, delta time.Duration) float64 {
+		return 1.0
+	}
+	s.AngularAccelerationOverLifetime = func(p *Particle, t NormalizedDuration, delta time.Duration) float64 {
+		return 0.5
+	}
+	s.Spawn(1)
+
+	now := time.Now()
+	s.Update(now)
+
+	for i := 0; i < 2; i++ {
+		now = now.Add(500 * time.Millisecond)
+		s.Update(now)
+	}
+
+	s.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.True(approxEqual(p.AngularVelocity(), 1.5))
+		is.True(approxEqual(p.Angle(), 0.5*1.25+0.5*1.5))
+	}, now)
+}
+
+func TestParticle_Update_SpinDamping(t *testing.T) {
+	is := is.New(t)
+
+	s := NewSystem()
+	s.MaxParticles = 1
+	s.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 10 * time.Second
+	}
+	s.InitialAngularVelocityOverTime = func(d time.Duration, delta time.Duration) float64 {
+		return -4.0
+	}
+	s.SpinDamping = math.Ln2
+	s.Spawn(1)
+
+	now := time.Now()
+	s.Update(now)
+
+	// damping is independent of the frame rate
+	for i := 0; i < 10; i++ {
+		now = now.Add(100 * time.Millisecond)
+		s.Update(now)
+	}
+
+	s.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.True(approxEqual(p.AngularVelocity(), -2.0))
+		is.True(p.Angle() >= 0 && p.Angle() < 2.0*math.Pi)
+	}, now)
+}
+
+func TestParticle_Update_Pivot(t *testing.T) {
+	is := is.New(t)
+
+	s := NewSystem()
+	s.MaxParticles = 1
+	s.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 10 * time.Second
+	}
+	s.PivotOverLifetime = func(p *Particle, t NormalizedDuration, delta time.Duration) Vector {
+		return Vector{0.0, float64(t) * 10.0}
+	}
+	s.Spawn(1)
+
+	now := time.Now()
+	s.Update(now)
+
+	now = now.Add(5 * time.Second)
+	s.Update(now)
+
+	s.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.True(approxEqualVector(p.Pivot(), Vector{0.0, 5.0}))
+	}, now)
+
+	snap := s.SnapshotForRender(now)
+	is.Equal(len(snap.Particles), 1)
+	is.True(approxEqualVector(snap.Particles[0].Pivot, Vector{0.0, 5.0}))
+}
+
+func TestParticle_Update_Skew(t *testing.T) {
+	is := is.New(t)
+
+	s := NewSystem()
+	s.MaxParticles = 1
+	s.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 10 * time.Second
+	}
+	s.SkewOverLifetime = func(p *Particle, t NormalizedDuration, delta time.Duration) Vector {
+		return Vector{float64(t), 0.0}
+	}
+	s.Spawn(1)
+
+	now := time.Now()
+	s.Update(now)
+
+	now = now.Add(5 * time.Second)
+	s.Update(now)
+
+	s.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.True(approxEqualVector(p.Skew(), Vector{0.5, 0.0}))
+	}, now)
+
+	snap := s.SnapshotForRender(now)
+	is.Equal(len(snap.Particles), 1)
+	is.True(approxEqualVector(snap.Particles[0].Skew, Vector{0.5, 0.0}))
+}
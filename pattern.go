@@ -0,0 +1,132 @@
+package twodeeparticles
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// An EmissionPattern determines where particles are spawned and which direction they are emitted into,
+// over the duration of a system. Patterns are deterministic, so that positions and directions of particles
+// spawned at the same time always match.
+//
+// Patterns are independent of emitter shapes (see EmitterShape), which spawn particles at random positions.
+// To emit particles from a shape into a pattern's directions, use PositionFromShape for EmissionPositionOverTime
+// and VelocityFromPattern for InitialVelocityOverTime. The pattern's EmissionDirection is then passed the position
+// chosen from the shape.
+type EmissionPattern interface {
+	// EmissionPosition returns the position of a particle that is being spawned after duration d has passed,
+	// relative to the system's origin.
+	EmissionPosition(d time.Duration) Vector
+
+	// EmissionDirection returns a unit vector pointing in the direction that a particle being spawned at spawnPos
+	// is emitted into, after duration d has passed.
+	EmissionDirection(d time.Duration, spawnPos Vector) Vector
+}
+
+// A Spiral is an EmissionPattern that emits particles outwards from a point on a circle that rotates over time.
+// To create multiple spiral arms, use ParticleSystem.EmissionSymmetry.
+type Spiral struct {
+	// StartAngle is the angle of the emission point at the start of the system, in radians.
+	StartAngle float64
+
+	// AngularSpeed is the speed at which the emission point rotates, in radians per second.
+	AngularSpeed float64
+
+	// Radius is the distance of the emission point from the system's origin.
+	Radius float64
+}
+
+// A ScanLine is an EmissionPattern that emits particles from a point moving along a line over time.
+type ScanLine struct {
+	// Start is the start of the line.
+	Start Vector
+
+	// End is the end of the line.
+	End Vector
+
+	// Period is the duration it takes the emission point to move from Start to End.
+	Period time.Duration
+
+	// PingPong moves the emission point back from End to Start after it has reached End, and so on. If PingPong
+	// is false, the emission point jumps back to Start instead.
+	PingPong bool
+
+	// Direction is the direction that particles are emitted into, in radians.
+	Direction float64
+}
+
+var (
+	_ EmissionPattern = Spiral{}
+	_ EmissionPattern = ScanLine{}
+	_ EmissionPattern = AngleSweep{}
+)
+
+// PositionFromPattern returns a function that can be used as ParticleSystem.EmissionPositionOverTime.
+func PositionFromPattern(pat EmissionPattern) VectorOverTimeFunc {
+	return func(d time.Duration, delta time.Duration) Vector {
+		return pat.EmissionPosition(d)
+	}
+}
+
+// VelocityFromPattern returns a function that can be used as ParticleSystem.InitialVelocityOverTime. Particles will be
+// emitted into the cone of width spread around the pattern's direction, in radians, with the given speed.
+func VelocityFromPattern(pat EmissionPattern, speed float64, spread float64, rand *rand.Rand) SpawnVectorOverTimeFunc {
+	return func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+		dir := pat.EmissionDirection(d, spawnPos)
+		if spread > 0 {
+			dir = dir.Rotate((rand.Float64() - 0.5) * spread)
+		}
+
+		return dir.Multiply(speed)
+	}
+}
+
+func (s Spiral) angle(d time.Duration) float64 {
+	return s.StartAngle + s.AngularSpeed*d.Seconds()
+}
+
+// EmissionPosition implements EmissionPattern.
+func (s Spiral) EmissionPosition(d time.Duration) Vector {
+	return VectorFromAngle(s.angle(d)).Multiply(s.Radius)
+}
+
+// EmissionDirection implements EmissionPattern.
+func (s Spiral) EmissionDirection(d time.Duration, spawnPos Vector) Vector {
+	return VectorFromAngle(s.angle(d))
+}
+
+// EmissionPosition implements EmissionPattern.
+func (l ScanLine) EmissionPosition(d time.Duration) Vector {
+	f := 0.0
+
+	if l.Period > 0 {
+		p := d.Seconds() / l.Period.Seconds()
+
+		if l.PingPong {
+			f = math.Mod(p, 2.0)
+			if f > 1.0 {
+				f = 2.0 - f
+			}
+		} else {
+			f = math.Mod(p, 1.0)
+		}
+	}
+
+	return VectorRange{l.Start, l.End}.Lerp(f)
+}
+
+// EmissionDirection implements EmissionPattern.
+func (l ScanLine) EmissionDirection(d time.Duration, spawnPos Vector) Vector {
+	return VectorFromAngle(l.Direction)
+}
+
+// EmissionPosition implements EmissionPattern. Particles are always spawned at the system's origin.
+func (s AngleSweep) EmissionPosition(d time.Duration) Vector {
+	return ZeroVector
+}
+
+// EmissionDirection implements EmissionPattern. Spread is not taken into account.
+func (s AngleSweep) EmissionDirection(d time.Duration, spawnPos Vector) Vector {
+	return s.Direction(d)
+}
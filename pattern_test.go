@@ -0,0 +1,90 @@
+package twodeeparticles
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestSpiral_EmissionPosition(t *testing.T) {
+	is := is.New(t)
+
+	s := Spiral{StartAngle: math.Pi / 2.0, AngularSpeed: math.Pi / 2.0, Radius: 10.0}
+
+	is.True(approxEqualVector(s.EmissionPosition(0), Vector{0, 10}))
+	is.True(approxEqualVector(s.EmissionPosition(1*time.Second), Vector{-10, 0}))
+	is.True(approxEqualVector(s.EmissionDirection(1*time.Second, ZeroVector), Vector{-1, 0}))
+}
+
+func TestScanLine_EmissionPosition(t *testing.T) {
+	is := is.New(t)
+
+	l := ScanLine{Start: Vector{-10, 5}, End: Vector{10, 5}, Period: 2 * time.Second, Direction: -math.Pi / 2.0}
+
+	is.Equal(l.EmissionPosition(0), Vector{-10, 5})
+	is.Equal(l.EmissionPosition(1*time.Second), Vector{0, 5})
+	is.Equal(l.EmissionPosition(3*time.Second), Vector{0, 5})
+	is.Equal(l.EmissionPosition(3500*time.Millisecond), Vector{5, 5})
+	is.True(approxEqualVector(l.EmissionDirection(0, ZeroVector), Vector{0, -1}))
+
+	l.PingPong = true
+
+	is.Equal(l.EmissionPosition(2*time.Second), Vector{10, 5})
+	is.Equal(l.EmissionPosition(3500*time.Millisecond), Vector{-5, 5})
+}
+
+func TestVelocityFromPattern(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 10
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return InfiniteLifetime
+	}
+
+	pat := Spiral{AngularSpeed: math.Pi, Radius: 10.0}
+	sys.EmissionPositionOverTime = PositionFromPattern(pat)
+	sys.InitialVelocityOverTime = VelocityFromPattern(pat, 5.0, 0.0, rand.New(rand.NewSource(1))) //nolint:gosec // no crypto
+	sys.EmissionRateOverTime = func(d time.Duration, delta time.Duration) float64 {
+		return 2.0
+	}
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(500 * time.Millisecond)
+	sys.Update(now)
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.True(approxEqualVector(p.SpawnPosition(), Vector{0, 10}))
+		is.True(approxEqualVector(p.Velocity(), Vector{0, 5}))
+	}, now)
+}
+
+func TestVelocityFromPattern_Shape(t *testing.T) {
+	is := is.New(t)
+
+	r := rand.New(rand.NewSource(1)) //nolint:gosec // no crypto
+
+	sys := NewSystem()
+	sys.MaxParticles = 10
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return InfiniteLifetime
+	}
+
+	rect := Rect{Min: Vector{-10, -10}, Max: Vector{10, 10}}
+	sys.EmissionPositionOverTime = PositionFromShape(rect, false, r)
+	sys.InitialVelocityOverTime = VelocityFromPattern(ScanLine{Direction: math.Pi / 2}, 5.0, 0.0, r)
+	sys.Spawn(10)
+
+	now := time.Now()
+	sys.Update(now)
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.True(rect.Contains(p.SpawnPosition()))
+		is.True(approxEqualVector(p.Velocity(), Vector{0, 5}))
+	}, now)
+}
@@ -0,0 +1,33 @@
+package twodeeparticles
+
+import "time"
+
+// A VectorRange is a range of vectors between Min and Max.
+type VectorRange struct {
+	Min Vector
+	Max Vector
+}
+
+// Lerp returns the vector at f within r, with f in the range [0.0,1.0]. Both components are interpolated using
+// the same f, so that uniform scales stay uniform.
+func (r VectorRange) Lerp(f float64) Vector {
+	return r.Min.Add(r.Max.Add(r.Min.Multiply(-1.0)).Multiply(f))
+}
+
+// ScaleFromTo returns a function that can be used as ParticleSystem.ScaleOverLifetime. Each particle's scale is
+// interpolated from a start scale to an end scale over its lifetime. The start and end scales are chosen randomly
+// for each particle from the ranges start and end, using Particle.Random. curve maps a particle's normalized duration
+// to the interpolation factor. If curve has no keys, scales are interpolated linearly.
+func ScaleFromTo(start VectorRange, end VectorRange, curve Curve) ParticleVectorOverNormalizedTimeFunc {
+	return func(p *Particle, t NormalizedDuration, delta time.Duration) Vector {
+		f := float64(t)
+		if len(curve.Keys) > 0 {
+			f = curve.Value(t)
+		}
+
+		s := start.Lerp(p.Random(randomScaleStart))
+		e := end.Lerp(p.Random(randomScaleEnd))
+
+		return VectorRange{s, e}.Lerp(f)
+	}
+}
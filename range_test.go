@@ -0,0 +1,52 @@
+package twodeeparticles
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestVectorRange_Lerp(t *testing.T) {
+	is := is.New(t)
+
+	r := VectorRange{Vector{1, 2}, Vector{3, 6}}
+
+	is.Equal(r.Lerp(0.0), Vector{1, 2})
+	is.Equal(r.Lerp(0.5), Vector{2, 4})
+	is.Equal(r.Lerp(1.0), Vector{3, 6})
+}
+
+func TestScaleFromTo(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 20
+	sys.ScaleOverLifetime = ScaleFromTo(
+		VectorRange{Vector{1, 1}, Vector{2, 2}},
+		VectorRange{Vector{5, 5}, Vector{10, 10}},
+		Curve{})
+	sys.Spawn(20)
+
+	now := time.Now()
+	sys.Update(now)
+
+	starts := map[*Particle]Vector{}
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.True(p.Scale().X >= 1 && p.Scale().X < 2)
+		is.Equal(p.Scale().X, p.Scale().Y)
+
+		starts[p] = p.Scale()
+	}, now)
+
+	is.True(len(starts) == 20)
+
+	now = now.Add(999 * time.Millisecond)
+	sys.Update(now)
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.True(p.Scale().X >= 4.9 && p.Scale().X < 10)
+		is.Equal(p.StartScale(), starts[p])
+	}, now)
+}
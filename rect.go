@@ -0,0 +1,57 @@
+package twodeeparticles
+
+import (
+	"math"
+	"math/rand"
+)
+
+// A Rect is an axis-aligned rectangle. Min is the corner with the smallest coordinates, Max is the corner
+// with the largest coordinates.
+type Rect struct {
+	Min Vector
+	Max Vector
+}
+
+// Width returns r's width.
+func (r Rect) Width() float64 {
+	return r.Max.X - r.Min.X
+}
+
+// Height returns r's height.
+func (r Rect) Height() float64 {
+	return r.Max.Y - r.Min.Y
+}
+
+// Contains returns whether v is inside of r. Points on r's Min edges are considered to be inside,
+// points on r's Max edges are not.
+func (r Rect) Contains(v Vector) bool {
+	return v.X >= r.Min.X && v.X < r.Max.X && v.Y >= r.Min.Y && v.Y < r.Max.Y
+}
+
+// RandomPoint returns a random point inside of r, or on its edge if edge is set.
+func (r Rect) RandomPoint(rand *rand.Rand, edge bool) Vector {
+	if !edge {
+		return Vector{r.Min.X + rand.Float64()*r.Width(), r.Min.Y + rand.Float64()*r.Height()}
+	}
+
+	return Polygon{[]Vector{r.Min, {r.Max.X, r.Min.Y}, r.Max, {r.Min.X, r.Max.Y}}}.randomEdgePoint(rand)
+}
+
+// wrap returns v wrapped around r toroidally, that is, a point leaving r on one side re-enters r on the opposite side.
+func (r Rect) wrap(v Vector) Vector {
+	w, h := r.Width(), r.Height()
+
+	if w > 0 && (v.X < r.Min.X || v.X >= r.Max.X) {
+		v.X = r.Min.X + floorMod(v.X-r.Min.X, w)
+	}
+
+	if h > 0 && (v.Y < r.Min.Y || v.Y >= r.Max.Y) {
+		v.Y = r.Min.Y + floorMod(v.Y-r.Min.Y, h)
+	}
+
+	return v
+}
+
+func floorMod(a float64, b float64) float64 {
+	return a - b*math.Floor(a/b)
+}
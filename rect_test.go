@@ -0,0 +1,40 @@
+package twodeeparticles
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestRect_Width(t *testing.T) {
+	is := is.New(t)
+	is.Equal(Rect{Vector{-3, 5}, Vector{17, 23}}.Width(), 20.0)
+}
+
+func TestRect_Height(t *testing.T) {
+	is := is.New(t)
+	is.Equal(Rect{Vector{-3, 5}, Vector{17, 23}}.Height(), 18.0)
+}
+
+func TestRect_Contains(t *testing.T) {
+	is := is.New(t)
+
+	r := Rect{Vector{-3, 5}, Vector{17, 23}}
+
+	is.True(r.Contains(Vector{0, 10}))
+	is.True(r.Contains(Vector{-3, 5}))
+	is.True(!r.Contains(Vector{17, 23}))
+	is.True(!r.Contains(Vector{-4, 10}))
+	is.True(!r.Contains(Vector{0, 30}))
+}
+
+func TestRect_wrap(t *testing.T) {
+	is := is.New(t)
+
+	r := Rect{Vector{-10, 0}, Vector{10, 5}}
+
+	is.Equal(r.wrap(Vector{3, 2}), Vector{3, 2})
+	is.Equal(r.wrap(Vector{12, 2}), Vector{-8, 2})
+	is.Equal(r.wrap(Vector{-13, 7}), Vector{7, 2})
+	is.Equal(r.wrap(Vector{10, -1}), Vector{-10, 4})
+}
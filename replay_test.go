@@ -0,0 +1,80 @@
+package twodeeparticles
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestRecorder_Record(t *testing.T) {
+	is := is.New(t)
+
+	calls := 0
+
+	sys := NewSystem()
+	sys.MaxParticles = 10
+	sys.Instances = []Vector{{1, 1}}
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		if d == 0 {
+			return 1500 * time.Millisecond
+		}
+
+		return 10 * time.Second
+	}
+	sys.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+		return Vector{1, 0}
+	}
+	sys.UpdateFunc = func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		calls++
+	}
+
+	rec := Recorder{}
+
+	now := time.Now()
+	sys.Spawn(2)
+	sys.Update(now)
+	rec.Record(sys, now)
+
+	now = now.Add(1 * time.Second)
+	sys.Spawn(1)
+	sys.Update(now)
+	rec.Record(sys, now)
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+	rec.Record(sys, now)
+
+	replay := rec.Replay()
+
+	is.Equal(len(replay.Frames), 3)
+	is.Equal(replay.Duration(), 2*time.Second)
+
+	is.Equal(replay.Frames[0].Spawned, []uint64{1, 2})
+	is.Equal(len(replay.Frames[0].Killed), 0)
+	is.Equal(replay.Frames[1].Spawned, []uint64{3})
+	is.Equal(len(replay.Frames[2].Spawned), 0)
+	is.Equal(replay.Frames[2].Killed, []uint64{1, 2})
+	is.Equal(replay.Frames[2].Instances, []Vector{{1, 1}})
+
+	is.Equal(len(replay.Frames[1].Particles), 3)
+	is.Equal(replay.Frames[1].Particles[0].ID, uint64(1))
+	is.Equal(replay.Frames[1].Particles[0].Position, Vector{1, 0})
+
+	callsBefore := calls
+
+	is.True(replay.FrameAt(-1*time.Second) == nil)
+	is.Equal(replay.FrameAt(1500*time.Millisecond), &replay.Frames[1])
+	is.Equal(replay.FrameAt(5*time.Second), &replay.Frames[2])
+
+	snap := replay.SnapshotAt(1500 * time.Millisecond)
+	is.Equal(len(snap.Particles), 3)
+	is.Equal(snap.Particles[2].Position, Vector{0, 0})
+	is.Equal(snap.Instances, []Vector{{1, 1}})
+	snap.Release()
+
+	is.Equal(calls, callsBefore)
+
+	rec.Reset()
+	is.Equal(len(rec.Replay().Frames), 0)
+}
@@ -0,0 +1,61 @@
+package twodeeparticles
+
+import "math/rand"
+
+// SetSeed sets the seed of the system's random number generator (see Rand.) If Deterministic is set, the seeds
+// of particles (see Particle.Random) are also derived from seed. Otherwise, they are taken from Rand. This way,
+// a given seed always produces the same sequence of particles, for example for replays or lockstep multiplayer games.
+// Reset reseeds the random number generator with the same seed.
+func (sys *ParticleSystem) SetSeed(seed uint64) {
+	sys.seed = seed
+	sys.seeded = true
+
+	if !sys.randSet {
+		sys.Rand().Seed(sys.initialSeed())
+	}
+}
+
+// Rand returns the system's random number generator. It should be passed to helpers of this package that accept
+// a *rand.Rand, so that all randomness of the system is controlled by its seed (see SetSeed.) If no seed has been set,
+// the random number generator is seeded with 0 if Deterministic is set, or randomly otherwise. The random number
+// generator is not safe for concurrent use.
+func (sys *ParticleSystem) Rand() *rand.Rand {
+	if sys.rand == nil {
+		sys.rand = rand.New(rand.NewSource(sys.initialSeed())) //nolint:gosec // no crypto
+	}
+
+	return sys.rand
+}
+
+// SetRand replaces the system's random number generator with r (see Rand.) Unless Deterministic is set, the seeds
+// of particles (see Particle.Random) are then also taken from r. This allows to drive the system using an external
+// source of randomness, for example one that is shared with the rest of a game, or one that returns fixed values
+// in tests. r is not reseeded by SetSeed or Reset. If r is nil, the system's own random number generator is used again.
+func (sys *ParticleSystem) SetRand(r *rand.Rand) {
+	sys.rand = r
+	sys.randSet = r != nil
+}
+
+// initialSeed returns the seed for the system's random number generator.
+func (sys *ParticleSystem) initialSeed() int64 {
+	switch {
+	case sys.seeded:
+		return int64(sys.seed)
+	case sys.Deterministic:
+		return 0
+	default:
+		return rand.Int63() //nolint:gosec // no crypto
+	}
+}
+
+// particleSeed returns a new seed for p.
+func (sys *ParticleSystem) particleSeed(p *Particle) uint64 {
+	switch {
+	case sys.Deterministic:
+		return hashUint64(p.id*0x9e3779b97f4a7c15 + sys.seed)
+	case sys.seeded || sys.randSet:
+		return sys.Rand().Uint64()
+	default:
+		return rand.Uint64() //nolint:gosec // no crypto
+	}
+}
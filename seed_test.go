@@ -0,0 +1,110 @@
+package twodeeparticles
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestParticleSystem_SetSeed(t *testing.T) {
+	is := is.New(t)
+
+	run := func(deterministic bool, seed uint64, reset bool) []float64 {
+		sys := NewSystem()
+		sys.MaxParticles = 10
+		sys.Deterministic = deterministic
+		sys.SetSeed(seed)
+		sys.EmissionPositionOverTime = PositionFromShape(Circle{Radius: 10}, false, sys.Rand())
+		sys.Spawn(10)
+
+		now := time.Now()
+		sys.Update(now)
+
+		if reset {
+			sys.Reset()
+			sys.Spawn(10)
+			sys.Update(now)
+		}
+
+		values := []float64{}
+
+		sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+			values = append(values, p.Position().X, p.Random(0))
+		}, now)
+
+		return values
+	}
+
+	for _, deterministic := range []bool{false, true} {
+		is.Equal(run(deterministic, 1, false), run(deterministic, 1, false))
+		is.Equal(run(deterministic, 1, false), run(deterministic, 1, true))
+		is.True(run(deterministic, 1, false)[1] != run(deterministic, 2, false)[1])
+	}
+}
+
+func TestParticleSystem_Rand(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.Deterministic = true
+
+	r := sys.Rand()
+	is.True(r == sys.Rand())
+
+	v := r.Float64()
+
+	sys.Reset()
+
+	is.Equal(r.Float64(), v)
+}
+
+func TestParticleSystem_SetRand(t *testing.T) {
+	is := is.New(t)
+
+	run := func(r *rand.Rand) []float64 {
+		sys := NewSystem()
+		sys.MaxParticles = 10
+		sys.SetRand(r)
+		sys.EmissionPositionOverTime = PositionFromShape(Circle{Radius: 10}, false, sys.Rand())
+		sys.Spawn(10)
+
+		now := time.Now()
+		sys.Update(now)
+
+		values := []float64{}
+
+		sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+			values = append(values, p.Position().X, p.Random(0))
+		}, now)
+
+		return values
+	}
+
+	newRand := func(seed int64) *rand.Rand {
+		return rand.New(rand.NewSource(seed)) //nolint:gosec // no crypto
+	}
+
+	is.Equal(run(newRand(1)), run(newRand(1)))
+	is.True(run(newRand(1))[1] != run(newRand(2))[1])
+
+	r := newRand(1)
+
+	sys := NewSystem()
+	sys.SetRand(r)
+	is.True(sys.Rand() == r)
+
+	v := newRand(1)
+	v.Float64()
+
+	r.Float64()
+	sys.SetSeed(5)
+	sys.Reset()
+
+	// r is not reseeded
+	is.Equal(r.Float64(), v.Float64())
+
+	sys.SetRand(nil)
+	is.True(sys.Rand() != r)
+}
@@ -0,0 +1,71 @@
+package twodeeparticles
+
+import "time"
+
+// A Seek steers particles towards one of their system's Targets. This can be used to create aura or possession effects
+// that hug an animated character, by updating the targets to the character's bone positions on every frame.
+type Seek struct {
+	// Target is the index of the target in ParticleSystem.Targets that particles are steered towards. If Target is
+	// negative, particles are steered towards the nearest target.
+	Target int
+
+	// Speed is the maximum speed of particles, in arbitrary units per second.
+	Speed float64
+
+	// Steering is the maximum change of a particle's velocity, in arbitrary units per second squared. If Steering is 0,
+	// particles turn towards the target instantly.
+	Steering float64
+
+	// ArriveRadius slows particles down when they get closer to the target than this distance, so that they come to
+	// a stop at the target instead of overshooting it. If ArriveRadius is 0, particles do not slow down.
+	ArriveRadius float64
+}
+
+// Velocity returns p's velocity after steering it towards the target for a duration of delta. It can be used as
+// ParticleSystem.VelocityOverLifetime. If the target does not exist, p's velocity is returned unchanged.
+func (s Seek) Velocity(p *Particle, t NormalizedDuration, delta time.Duration) Vector {
+	sys := p.system
+
+	idx := s.Target
+	if idx < 0 {
+		idx = sys.NearestTarget(p.position)
+	}
+
+	if idx < 0 || idx >= len(sys.Targets) {
+		return p.velocity
+	}
+
+	diff := sys.offset(p.position, sys.Targets[idx])
+	dist := diff.Magnitude()
+
+	dir, ok := diff.TryNormalize()
+	if !ok {
+		dir = ZeroVector
+	}
+
+	speed := s.Speed
+	if s.ArriveRadius > 0 && dist < s.ArriveRadius {
+		speed *= dist / s.ArriveRadius
+	}
+
+	desired := dir.Multiply(speed)
+
+	if s.Steering <= 0 {
+		return desired
+	}
+
+	steer := desired.Add(p.velocity.Multiply(-1.0))
+	maxSteer := s.Steering * delta.Seconds()
+
+	if m := steer.Magnitude(); m > maxSteer {
+		steer = steer.Multiply(maxSteer / m)
+	}
+
+	v := p.velocity.Add(steer)
+
+	if m := v.Magnitude(); m > s.Speed {
+		v = v.Multiply(s.Speed / m)
+	}
+
+	return v
+}
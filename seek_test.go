@@ -0,0 +1,96 @@
+package twodeeparticles
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestSeek_Velocity(t *testing.T) {
+	is := is.New(t)
+
+	s := NewSystem()
+	s.MaxParticles = 1
+	s.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return InfiniteLifetime
+	}
+	s.Targets = []Vector{{100, 0}, {0, 100}}
+	s.VelocityOverLifetime = Seek{Target: 1, Speed: 10}.Velocity
+	s.Spawn(1)
+
+	now := time.Now()
+	s.Update(now)
+
+	now = now.Add(1 * time.Second)
+	s.Update(now)
+
+	s.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.Equal(p.Velocity(), Vector{0, 10})
+		is.Equal(p.Position(), Vector{0, 10})
+	}, now)
+
+	s.Targets[1] = Vector{0, -100}
+
+	now = now.Add(1 * time.Second)
+	s.Update(now)
+
+	s.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.Equal(p.Velocity(), Vector{0, -10})
+	}, now)
+}
+
+func TestSeek_Velocity_Steering(t *testing.T) {
+	is := is.New(t)
+
+	s := NewSystem()
+	s.MaxParticles = 1
+	s.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return InfiniteLifetime
+	}
+	s.Targets = []Vector{{100, 0}}
+	s.VelocityOverLifetime = Seek{Target: -1, Speed: 10, Steering: 4, ArriveRadius: 50}.Velocity
+	s.Spawn(1)
+
+	now := time.Now()
+	s.Update(now)
+
+	now = now.Add(1 * time.Second)
+	s.Update(now)
+
+	s.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.Equal(p.Velocity(), Vector{4, 0})
+	}, now)
+
+	for i := 0; i < 600; i++ {
+		now = now.Add(100 * time.Millisecond)
+		s.Update(now)
+	}
+
+	s.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.True(p.Position().Add(Vector{-100, 0}).Magnitude() < 1)
+		is.True(p.Velocity().Magnitude() < 1)
+	}, now)
+}
+
+func TestSeek_Velocity_NoTarget(t *testing.T) {
+	is := is.New(t)
+
+	s := NewSystem()
+	s.MaxParticles = 1
+	s.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+		return Vector{1, 2}
+	}
+	s.VelocityOverLifetime = Seek{Target: 3, Speed: 10}.Velocity
+	s.Spawn(1)
+
+	now := time.Now()
+	s.Update(now)
+
+	now = now.Add(100 * time.Millisecond)
+	s.Update(now)
+
+	s.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.Equal(p.Velocity(), Vector{1, 2})
+	}, now)
+}
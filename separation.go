@@ -0,0 +1,61 @@
+package twodeeparticles
+
+import (
+	"math"
+	"time"
+)
+
+// A Separation pushes particles apart that are closer to each other than a radius. This prevents particles in dense
+// effects, such as crowded smoke puffs, from visibly overlapping into a single blob. Unlike flocking, particles
+// only avoid each other, and do not align or group up.
+type Separation struct {
+	// Radius is the distance below which particles push each other apart. If Radius is 0, particles are not
+	// pushed apart.
+	Radius float64
+
+	// Strength is the velocity change of two particles at the same position, in arbitrary units per second squared.
+	// The velocity change decreases linearly with the distance, reaching zero at Radius.
+	Strength float64
+}
+
+// applySeparation changes the velocities of particles according to Separation.
+func (sys *ParticleSystem) applySeparation(now time.Time) {
+	sep := sys.Separation
+	if sep.Radius <= 0 {
+		return
+	}
+
+	sec := now.Sub(sys.lastUpdateTime).Seconds()
+	if sec <= 0 {
+		return
+	}
+
+	sys.buildGrid(sep.Radius)
+
+	for _, p := range sys.particles {
+		if !p.isAlive {
+			continue
+		}
+
+		push := ZeroVector
+
+		sys.forEachNear(p.position, sep.Radius, func(other *Particle, diff Vector, dist float64) {
+			if other == p {
+				return
+			}
+
+			dir, ok := diff.TryNormalize()
+			if !ok {
+				// same position, push apart in a direction that is different for each particle
+				dir = VectorFromAngle(p.Random(randomSeparationAngle) * 2.0 * math.Pi)
+				push = push.Add(dir.Multiply(sep.Strength))
+
+				return
+			}
+
+			push = push.Add(dir.Multiply(-sep.Strength * (1.0 - dist/sep.Radius)))
+		})
+
+		p.velocity = p.velocity.Add(push.Multiply(sec))
+	}
+}
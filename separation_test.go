@@ -0,0 +1,48 @@
+package twodeeparticles
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestParticleSystem_Update_Separation(t *testing.T) {
+	is := is.New(t)
+
+	positions := []Vector{{0, 0}, {2, 0}, {10, 0}, {10, 0}}
+
+	sys := NewSystem()
+	sys.MaxParticles = len(positions)
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return InfiniteLifetime
+	}
+	sys.Separation = Separation{Radius: 4.0, Strength: 10.0}
+
+	idx := 0
+	sys.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) Vector {
+		pos := positions[idx]
+		idx++
+
+		return pos
+	}
+
+	sys.Spawn(len(positions))
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(100 * time.Millisecond)
+	sys.Update(now)
+
+	var velocities []Vector
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		velocities = append(velocities, p.Velocity())
+	}, now)
+
+	is.True(approxEqualVector(velocities[0], Vector{-0.5, 0}))
+	is.True(approxEqualVector(velocities[1], Vector{0.5, 0}))
+	is.True(approxEqual(velocities[2].Magnitude(), 1.0))
+	is.True(approxEqual(velocities[3].Magnitude(), 1.0))
+}
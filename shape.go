@@ -0,0 +1,9 @@
+package twodeeparticles
+
+// A Shape is a geometric area.
+type Shape interface {
+	// Contains returns whether v is inside of the shape.
+	Contains(v Vector) bool
+}
+
+var _ Shape = Rect{}
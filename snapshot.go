@@ -0,0 +1,115 @@
+package twodeeparticles
+
+import (
+	"image/color"
+	"sync"
+	"time"
+)
+
+// A RenderSnapshot is an immutable copy of the states of a system's particles that are relevant for rendering.
+// Since a snapshot does not share any state with its system, it can be rendered on a different goroutine while
+// the system continues to be updated.
+//
+// Snapshots are pooled. When a snapshot is no longer needed, it should be returned using Release.
+type RenderSnapshot struct {
+	// Particles are the states of the system's alive and visible particles, in the order they have been spawned.
+	Particles []RenderParticle
+
+	// Instances are the origins that the particles should be drawn at, relative to the system's origin
+	// (see ParticleSystem.Instances.)
+	Instances []Vector
+}
+
+// RenderInstanceFunc is a function that is called for each particle of each instance when iterating over the instances
+// of a RenderSnapshot. p's position is relative to the system's origin, and already includes the instance's origin.
+type RenderInstanceFunc func(instance int, p RenderParticle)
+
+// A RenderParticle is the state of a particle that is relevant for rendering, at the time a RenderSnapshot
+// has been taken.
+type RenderParticle struct {
+	// Position is the particle's position, relative to its system's origin.
+	Position Vector
+
+	// Scale is the particle's scale.
+	Scale Vector
+
+	// Pivot is the point of the particle's sprite that is placed at Position, and that the sprite is rotated and
+	// scaled around, relative to the sprite's center (see ParticleSystem.PivotOverLifetime.)
+	Pivot Vector
+
+	// Skew is the particle's skew angles, in radians. The sprite is skewed after it has been scaled, but before it
+	// is rotated (see ParticleSystem.SkewOverLifetime.)
+	Skew Vector
+
+	// Angle is the particle's rotation angle, in radians.
+	Angle float64
+
+	// Color is the particle's color.
+	Color color.Color
+
+	// T is the particle's normalized duration during its lifetime.
+	T NormalizedDuration
+}
+
+var renderSnapshotPool = sync.Pool{
+	New: func() any {
+		return &RenderSnapshot{}
+	},
+}
+
+// SnapshotForRender returns a snapshot of the states of all alive and visible particles. now should usually be
+// time.Now().
+func (sys *ParticleSystem) SnapshotForRender(now time.Time) *RenderSnapshot {
+	snap := renderSnapshotPool.Get().(*RenderSnapshot) //nolint:forcetypeassert // we know this is a *RenderSnapshot
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		if !p.visible {
+			return
+		}
+
+		snap.Particles = append(snap.Particles, p.renderParticle(t))
+	}, now)
+
+	snap.Instances = append(snap.Instances, sys.Instances...)
+
+	return snap
+}
+
+// renderParticle returns the state of p that is relevant for rendering, at p's normalized duration t.
+func (p *Particle) renderParticle(t NormalizedDuration) RenderParticle {
+	return RenderParticle{
+		Position: p.position,
+		Scale:    p.scale,
+		Pivot:    p.pivot,
+		Skew:     p.skew,
+		Angle:    p.angle,
+		Color:    p.color,
+		T:        t,
+	}
+}
+
+// ForEachInstance calls fun for each particle of each instance in s (see ParticleSystem.Instances.) If s has no
+// instances, fun is called once for each particle, with instance 0 and the particle's original position.
+func (s *RenderSnapshot) ForEachInstance(fun RenderInstanceFunc) {
+	if len(s.Instances) == 0 {
+		for _, p := range s.Particles {
+			fun(0, p)
+		}
+
+		return
+	}
+
+	for idx, origin := range s.Instances {
+		for _, p := range s.Particles {
+			p.Position = p.Position.Add(origin)
+			fun(idx, p)
+		}
+	}
+}
+
+// Release returns s back into the pool. s must not be used afterwards.
+func (s *RenderSnapshot) Release() {
+	s.Particles = s.Particles[:0]
+	s.Instances = s.Instances[:0]
+	renderSnapshotPool.Put(s)
+}
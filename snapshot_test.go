@@ -0,0 +1,155 @@
+package twodeeparticles
+
+import (
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestParticleSystem_SnapshotForRender(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+
+	sys.MaxParticles = 3
+
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 2 * time.Second
+	}
+
+	sys.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) Vector {
+		return Vector{17, 23}
+	}
+
+	sys.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+		return Vector{3, 5}
+	}
+
+	sys.ColorOverLifetime = func(p *Particle, t NormalizedDuration, delta time.Duration) color.Color {
+		return color.RGBA{0x12, 0x23, 0x34, 0x45}
+	}
+
+	sys.Spawn(3)
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	snap := sys.SnapshotForRender(now)
+	defer snap.Release()
+
+	now = now.Add(500 * time.Millisecond)
+	sys.Update(now)
+
+	is.Equal(len(snap.Particles), 3)
+
+	for _, p := range snap.Particles {
+		is.Equal(p, RenderParticle{
+			Position: Vector{20, 28},
+			Scale:    OneVector,
+			Color:    color.RGBA{0x12, 0x23, 0x34, 0x45},
+			T:        0.5,
+		})
+	}
+}
+
+func TestRenderSnapshot_Concurrent(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 100
+	sys.EmissionRateOverTime = func(d time.Duration, delta time.Duration) float64 {
+		return 1000.0
+	}
+	sys.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+		return Vector{3, 5}
+	}
+
+	snaps := make(chan *RenderSnapshot)
+	done := make(chan int)
+
+	go func() {
+		num := 0
+
+		for snap := range snaps {
+			for _, p := range snap.Particles {
+				num++
+				_ = p.Position.Add(p.Scale)
+				_, _, _, _ = p.Color.RGBA()
+			}
+
+			snap.Release()
+		}
+
+		done <- num
+	}()
+
+	now := time.Now()
+
+	for i := 0; i < 50; i++ {
+		now = now.Add(10 * time.Millisecond)
+		sys.Update(now)
+
+		snaps <- sys.SnapshotForRender(now)
+
+		sys.Spawn(1)
+		sys.Update(now.Add(time.Millisecond))
+	}
+
+	close(snaps)
+
+	is.True(<-done > 0)
+}
+
+func TestRenderSnapshot_ForEachInstance(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 2
+	sys.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) Vector {
+		return Vector{1, 2}
+	}
+
+	sys.Spawn(2)
+
+	now := time.Now()
+	sys.Update(now)
+
+	snap := sys.SnapshotForRender(now)
+
+	var positions []Vector
+
+	snap.ForEachInstance(func(instance int, p RenderParticle) {
+		is.Equal(instance, 0)
+		positions = append(positions, p.Position)
+	})
+
+	is.Equal(positions, []Vector{{1, 2}, {1, 2}})
+
+	snap.Release()
+
+	sys.Instances = []Vector{{10, 0}, {0, 10}, {-10, 0}}
+
+	snap = sys.SnapshotForRender(now)
+	defer snap.Release()
+
+	sys.Instances[0] = ZeroVector
+
+	positions = nil
+	instances := map[int]int{}
+
+	snap.ForEachInstance(func(instance int, p RenderParticle) {
+		instances[instance]++
+		positions = append(positions, p.Position)
+	})
+
+	is.Equal(instances, map[int]int{0: 2, 1: 2, 2: 2})
+	is.Equal(positions, []Vector{{11, 2}, {11, 2}, {1, 12}, {1, 12}, {-9, 2}, {-9, 2}})
+
+	// the snapshot itself is unchanged
+	is.Equal(snap.Particles[0].Position, Vector{1, 2})
+}
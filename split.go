@@ -0,0 +1,127 @@
+package twodeeparticles
+
+import (
+	"image/color"
+	"time"
+)
+
+// ParticleState is the state of a particle.
+type ParticleState struct {
+	// Position is the particle's position, relative to its system's origin.
+	Position Vector
+
+	// Velocity is the particle's velocity.
+	Velocity Vector
+
+	// Scale is the particle's scale (size multiplier).
+	Scale Vector
+
+	// Angle is the particle's rotation angle, in radians.
+	Angle float64
+
+	// AngularVelocity is the particle's angular velocity, in radians per second.
+	AngularVelocity float64
+
+	// Color is the particle's color.
+	Color color.Color
+
+	// Data is the particle's arbitrary data (see Particle.Data.)
+	Data any
+
+	// Lifetime is the particle's maximum lifetime.
+	Lifetime time.Duration
+}
+
+// SplitFunc is a function that is called for each child particle when a particle is split. state is pre-initialized
+// with the parent's state, except for Data, which is nil. It can be modified to change the child's state. idx is
+// the index of the child.
+type SplitFunc func(parent *Particle, idx int, state *ParticleState)
+
+// State returns p's current state.
+func (p *Particle) State() ParticleState {
+	return ParticleState{
+		Position:        p.position,
+		Velocity:        p.velocity,
+		Scale:           p.scale,
+		Angle:           p.angle,
+		AngularVelocity: p.angularVelocity,
+		Color:           p.color,
+		Data:            p.data,
+		Lifetime:        p.lifetime,
+	}
+}
+
+// Split spawns num new particles that inherit p's state, as of p's most recent update. The new particles are spawned
+// at the time of p's most recent update, and their lifetimes are set to p's remaining lifetime. If fun is not nil,
+// it is called for each new particle, and can change its state (for example, to spread the particles' velocities
+// apart.) If kill is set, p is killed. This can be used for fragmentation effects, for example a firework shell
+// bursting into sparks, usually from a timer (see After.)
+//
+// The new particles are subject to MaxParticles and TotalEmissionLimit. If Split is called while the system's particles
+// are being iterated over (for example, from a timer), spawning the particles is deferred until the iteration has
+// finished. On their first update, the new particles catch up from the time they have been spawned at.
+//
+// The new particles do not inherit p's Data, so that no two particles share the same data (for example, if DeathFunc
+// releases it to a pool.) To give them data, set it in fun, or use DataOverLifetime.
+func (p *Particle) Split(num int, fun SplitFunc, kill bool) {
+	sys := p.system
+	now := p.lastUpdateTime
+
+	parent := p.State()
+	parent.Data = nil
+
+	if parent.Lifetime != InfiniteLifetime {
+		parent.Lifetime = p.deathTime.Sub(now)
+	}
+
+	states := make([]ParticleState, num)
+
+	for idx := range states {
+		states[idx] = parent
+
+		if fun != nil {
+			fun(p, idx, &states[idx])
+		}
+	}
+
+	if kill {
+		p.Kill()
+	}
+
+	spawn := func() {
+		for _, s := range states {
+			if !sys.canSpawn() {
+				return
+			}
+
+			sys.spawnParticleWithState(now, s)
+		}
+	}
+
+	if sys.deferIfIterating(spawn) {
+		return
+	}
+
+	spawn()
+}
+
+// spawnParticleWithState spawns a particle with the given state, bypassing the emission callbacks.
+func (sys *ParticleSystem) spawnParticleWithState(now time.Time, state ParticleState) {
+	part := sys.newParticle(now)
+
+	part.position = state.Position
+	part.velocity = state.Velocity
+	part.scale = state.Scale
+	part.angle = state.Angle
+	part.angularVelocity = state.AngularVelocity
+	part.color = state.Color
+	part.data = state.Data
+	part.SetLifetime(state.Lifetime)
+
+	if part.color == nil {
+		part.color = white
+	}
+
+	sys.particles = append(sys.particles, part)
+	sys.numEmitted++
+}
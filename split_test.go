@@ -0,0 +1,135 @@
+package twodeeparticles
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestParticle_Split(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 3
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 2 * time.Second
+	}
+	sys.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+		return Vector{10, 0}
+	}
+	sys.DataOverLifetime = func(old any, t NormalizedDuration, delta time.Duration) any {
+		if old == nil {
+			return "shell"
+		}
+
+		return old
+	}
+
+	sys.UpdateFunc = func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		if p.Age() > 0 || p.ID() != 1 {
+			return
+		}
+
+		p.After(500*time.Millisecond, func(p *Particle) {
+			// only two of the three children fit
+			p.Split(3, func(parent *Particle, idx int, state *ParticleState) {
+				state.Velocity = state.Velocity.Rotate(float64(idx*2-1) * 0.5)
+			}, true)
+		})
+	}
+
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(time.Second)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 2)
+
+	var children []*Particle
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		children = append(children, p)
+	}, now)
+
+	is.Equal(len(children), 2)
+
+	// the children have been spawned at the exact time of the split, and have caught up since
+	for idx, c := range children {
+		is.Equal(c.ID(), uint64(idx+2))
+		is.Equal(c.Age(), 500*time.Millisecond)
+		is.True(approxEqualVector(c.SpawnPosition(), Vector{5, 0}))
+		is.True(approxEqualVector(c.Position(), Vector{5, 0}.Add(Vector{5, 0}.Rotate(float64(idx*2-1)*0.5))))
+		is.True(approxEqualVector(c.Velocity(), Vector{10, 0}.Rotate(float64(idx*2-1)*0.5)))
+		is.Equal(c.Data(), "shell")
+		is.Equal(c.Lifetime(), 1500*time.Millisecond)
+	}
+
+	now = now.Add(time.Second)
+	sys.Update(now)
+
+	// the children die when the parent would have died
+	is.Equal(sys.NumParticles(), 0)
+}
+
+func TestParticle_Split_Data(t *testing.T) {
+	is := is.New(t)
+
+	type data struct {
+		released int
+	}
+
+	sys := NewSystem()
+	sys.MaxParticles = 4
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return time.Second
+	}
+	sys.DataOverLifetime = func(old any, t NormalizedDuration, delta time.Duration) any {
+		if old == nil {
+			return &data{}
+		}
+
+		return old
+	}
+	sys.DeathFunc = func(p *Particle) {
+		p.Data().(*data).released++ //nolint:forcetypeassert // we know this is a *data
+	}
+
+	var all []*data
+
+	sys.UpdateFunc = func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		if p.ID() == 1 && p.Age() == 0 {
+			p.After(50*time.Millisecond, func(p *Particle) {
+				p.Split(3, nil, false)
+			})
+		}
+	}
+
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	for i := 0; i < 2; i++ {
+		now = now.Add(100 * time.Millisecond)
+		sys.Update(now)
+	}
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		all = append(all, p.Data().(*data)) //nolint:forcetypeassert // we know this is a *data
+	}, now)
+
+	is.Equal(len(all), 4)
+
+	now = now.Add(2 * time.Second)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 0)
+
+	for _, d := range all {
+		is.Equal(d.released, 1)
+	}
+}
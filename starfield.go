@@ -0,0 +1,111 @@
+package twodeeparticles
+
+import (
+	"image/color"
+	"math/rand"
+	"time"
+)
+
+// A Starfield is a preset that simulates a scrolling starfield, for example as a background effect. Stars are spread
+// across the system's WrapBounds and move with a velocity that depends on their depth layer, creating a parallax
+// effect. Instead of dying, stars leaving the bounds wrap around to the opposite side.
+//
+// The Data of a starfield particle is a *Star.
+type Starfield struct {
+	// Velocity is the velocity of stars in a layer with a speed of 1.0, in arbitrary units per second.
+	Velocity Vector
+
+	// Layers are the depth layers that stars are spawned into.
+	Layers []DepthLayer
+
+	sys  *ParticleSystem
+	rand *rand.Rand
+}
+
+// A Star is the data attached to a starfield particle.
+type Star struct {
+	// Layer is the index of the star's depth layer in Starfield.Layers.
+	Layer int
+}
+
+// NewStarfield returns a new starfield with numStars stars spread across bounds.
+func NewStarfield(bounds Rect, numStars int, rand *rand.Rand) *Starfield {
+	s := Starfield{
+		Velocity: Vector{-100.0, 0.0},
+		Layers: []DepthLayer{
+			{Weight: 3.0, Speed: 0.25, Scale: 0.05, Alpha: 0.4},
+			{Weight: 2.0, Speed: 0.5, Scale: 0.1, Alpha: 0.7},
+			{Weight: 1.0, Speed: 1.0, Scale: 0.15, Alpha: 1.0},
+		},
+		sys:  NewSystem(),
+		rand: rand,
+	}
+
+	s.sys.MaxParticles = numStars
+	s.sys.WrapBounds = bounds
+	s.sys.LifetimeOverTime = s.lifetime
+	s.sys.EmissionPositionOverTime = s.emissionPosition
+	s.sys.DataOverLifetime = s.data
+	s.sys.VelocityOverLifetime = s.velocity
+	s.sys.ScaleOverLifetime = s.scale
+	s.sys.ColorOverLifetime = s.color
+
+	s.sys.Spawn(numStars)
+
+	return &s
+}
+
+// System returns the particle system that simulates s.
+func (s *Starfield) System() *ParticleSystem {
+	return s.sys
+}
+
+func (s *Starfield) lifetime(d time.Duration, delta time.Duration) time.Duration {
+	return InfiniteLifetime
+}
+
+func (s *Starfield) emissionPosition(d time.Duration, delta time.Duration) Vector {
+	b := s.sys.WrapBounds
+
+	return Vector{
+		b.Min.X + s.rand.Float64()*b.Width(),
+		b.Min.Y + s.rand.Float64()*b.Height(),
+	}
+}
+
+func (s *Starfield) data(old any, t NormalizedDuration, delta time.Duration) any {
+	if old != nil {
+		return old
+	}
+
+	return &Star{
+		Layer: chooseLayer(s.Layers, s.rand),
+	}
+}
+
+func (s *Starfield) velocity(p *Particle, t NormalizedDuration, delta time.Duration) Vector {
+	star := p.Data().(*Star) //nolint:forcetypeassert // we know this is a *Star
+	return s.Velocity.Multiply(s.layer(star).Speed)
+}
+
+func (s *Starfield) scale(p *Particle, t NormalizedDuration, delta time.Duration) Vector {
+	star := p.Data().(*Star) //nolint:forcetypeassert // we know this is a *Star
+	sc := s.layer(star).Scale
+
+	return Vector{sc, sc}
+}
+
+func (s *Starfield) color(p *Particle, t NormalizedDuration, delta time.Duration) color.Color {
+	star := p.Data().(*Star) //nolint:forcetypeassert // we know this is a *Star
+	a := s.layer(star).Alpha
+
+	return color.NRGBA{255, 255, 255, uint8(a * 255.0)}
+}
+
+func (s *Starfield) layer(star *Star) DepthLayer {
+	if star.Layer < 0 || star.Layer >= len(s.Layers) {
+		return DepthLayer{Weight: 1.0, Speed: 1.0, Scale: 1.0, Alpha: 1.0}
+	}
+
+	return s.Layers[star.Layer]
+}
@@ -0,0 +1,44 @@
+package twodeeparticles
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestStarfield(t *testing.T) {
+	is := is.New(t)
+
+	bounds := Rect{Vector{-100, -50}, Vector{100, 50}}
+	s := NewStarfield(bounds, 100, rand.New(rand.NewSource(1))) //nolint:gosec // no crypto
+	sys := s.System()
+
+	now := time.Now()
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 100)
+
+	positions := map[*Particle]Vector{}
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.True(bounds.Contains(p.Position()))
+		positions[p] = p.Position()
+	}, now)
+
+	for i := 0; i < 10; i++ {
+		now = now.Add(500 * time.Millisecond)
+		sys.Update(now)
+	}
+
+	is.Equal(sys.NumParticles(), 100)
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		star := p.Data().(*Star) //nolint:forcetypeassert // we know this is a *Star
+		speed := s.Velocity.Multiply(s.Layers[star.Layer].Speed)
+
+		is.True(p.Position().X >= bounds.Min.X+speed.X*0.5 && p.Position().X < bounds.Max.X)
+		is.Equal(p.Position().Y, positions[p].Y)
+	}, now)
+}
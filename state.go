@@ -0,0 +1,209 @@
+package twodeeparticles
+
+import (
+	"image/color"
+	"time"
+)
+
+// A SystemState is the saved state of a system and all of its alive particles (see ParticleSystem.Snapshot.)
+// It only consists of plain values, so that it can be serialized, for example using encoding/gob or encoding/json,
+// to save and load games mid-effect.
+type SystemState struct {
+	// Duration is the duration of the system (see ParticleSystem.Duration.)
+	Duration time.Duration
+
+	// NumEmitted is the number of particles spawned (see ParticleSystem.NumEmitted.)
+	NumEmitted int
+
+	// ParticlesToEmit is the fractional number of particles that are due to be spawned, but have not been spawned yet.
+	ParticlesToEmit float64
+
+	// Bursts are the bursts that are due to be spawned on the next update (see ParticleSystem.SpawnBurst.)
+	Bursts []Burst
+
+	// Stopped is whether the system has been stopped (see ParticleSystem.Stop.)
+	Stopped bool
+
+	// Completed is whether the system has completed, and CompleteFunc has been called (see ParticleSystem.CompleteFunc.)
+	Completed bool
+
+	// Particles are the states of all alive particles, in the order they have been spawned.
+	Particles []SavedParticle
+}
+
+// A SavedParticle is the saved state of a particle.
+type SavedParticle struct {
+	// ID is the particle's ID (see Particle.ID.)
+	ID uint64
+
+	// Seed is the particle's random seed (see Particle.Random.)
+	Seed uint64
+
+	// Position is the particle's position, relative to its system's origin.
+	Position Vector
+
+	// Velocity is the particle's velocity.
+	Velocity Vector
+
+	// Scale is the particle's scale (size multiplier).
+	Scale Vector
+
+	// Pivot is the particle's pivot (see Particle.Pivot.)
+	Pivot Vector
+
+	// Skew is the particle's skew (see Particle.Skew.)
+	Skew Vector
+
+	// Angle is the particle's rotation angle, in radians.
+	Angle float64
+
+	// AngularVelocity is the particle's angular velocity, in radians per second.
+	AngularVelocity float64
+
+	// Color is the particle's color.
+	Color color.RGBA64
+
+	// Visible is whether the particle is visible.
+	Visible bool
+
+	// Lifetime is the particle's maximum lifetime.
+	Lifetime time.Duration
+
+	// Age is the particle's age, that is, the time since it has been born.
+	Age time.Duration
+
+	// Distance is the distance the particle has travelled (see Particle.DistanceTravelled.)
+	Distance float64
+
+	// SpawnPosition is the particle's position after its first update (see Particle.SpawnPosition.)
+	SpawnPosition Vector
+
+	// StartVelocity is the particle's velocity after its first update (see Particle.StartVelocity.)
+	StartVelocity Vector
+
+	// StartScale is the particle's scale after its first update (see Particle.StartScale.)
+	StartScale Vector
+
+	// StartColor is the particle's color after its first update (see Particle.StartColor.)
+	StartColor color.RGBA64
+}
+
+// Snapshot returns the state of the system and all of its alive particles at now, so that it can be restored later
+// using Restore. now should usually be time.Now().
+//
+// The data of particles (see Particle.Data) and their timers (see After) are not saved. After the state has been
+// restored, DataOverLifetime is called with nil old data, as for new particles.
+func (sys *ParticleSystem) Snapshot(now time.Time) SystemState {
+	now = sys.clock.at(now)
+
+	state := SystemState{
+		Duration:        sys.duration(now),
+		NumEmitted:      sys.numEmitted,
+		ParticlesToEmit: sys.particlesToEmit,
+		Bursts:          append([]Burst(nil), sys.bursts...),
+		Stopped:         sys.stopped,
+		Completed:       sys.completed,
+		Particles:       make([]SavedParticle, 0, len(sys.particles)),
+	}
+
+	for _, p := range sys.particles {
+		if !p.isAlive {
+			continue
+		}
+
+		state.Particles = append(state.Particles, SavedParticle{
+			ID:              p.id,
+			Seed:            p.seed,
+			Position:        p.position,
+			Velocity:        p.velocity,
+			Scale:           p.scale,
+			Pivot:           p.pivot,
+			Skew:            p.skew,
+			Angle:           p.angle,
+			AngularVelocity: p.angularVelocity,
+			Color:           toRGBA64(p.color),
+			Visible:         p.visible,
+			Lifetime:        p.lifetime,
+			Age:             now.Sub(p.birthTime),
+			Distance:        p.distance,
+			SpawnPosition:   p.spawnPosition,
+			StartVelocity:   p.startVelocity,
+			StartScale:      p.startScale,
+			StartColor:      toRGBA64(p.startColor),
+		})
+	}
+
+	return state
+}
+
+// Restore resets the system (see Reset), and then restores state as of now, as returned by Snapshot. The system's
+// configuration is not changed. now should usually be time.Now().
+//
+// If Restore is called while the system's particles are being iterated over (for example, from a function passed
+// to ForEachParticle), restoring is deferred until the iteration has finished.
+func (sys *ParticleSystem) Restore(state SystemState, now time.Time) {
+	if sys.deferIfIterating(func() { sys.Restore(state, now) }) {
+		return
+	}
+
+	sys.Reset()
+
+	sys.initOnce.Do(func() {
+		sys.clock.init(now)
+		sys.startTime = now.Add(-state.Duration)
+		sys.lastUpdateTime = now
+		sys.preallocateParticles()
+	})
+
+	for _, s := range state.Particles {
+		part := sys.newParticle(now.Add(-s.Age))
+
+		part.id = s.ID
+		part.seed = s.Seed
+		part.updated = true
+		part.lastUpdateTime = now
+		part.age = s.Age
+		part.position = s.Position
+		part.velocity = s.Velocity
+		part.scale = s.Scale
+		part.pivot = s.Pivot
+		part.skew = s.Skew
+		part.angle = s.Angle
+		part.angularVelocity = s.AngularVelocity
+		part.color = s.Color
+		part.visible = s.Visible
+		part.distance = s.Distance
+		part.spawnPosition = s.SpawnPosition
+		part.startVelocity = s.StartVelocity
+		part.startScale = s.StartScale
+		part.startColor = s.StartColor
+		part.SetLifetime(s.Lifetime)
+
+		if s.ID > sys.lastID {
+			sys.lastID = s.ID
+		}
+
+		sys.particles = append(sys.particles, part)
+	}
+
+	sys.numEmitted = state.NumEmitted
+	sys.particlesToEmit = state.ParticlesToEmit
+	sys.bursts = append(sys.bursts, state.Bursts...)
+	sys.stopped = state.Stopped
+	sys.completed = state.Completed
+
+	for _, f := range sys.periodicFuncs {
+		for f.next <= state.Duration {
+			f.next += f.interval
+		}
+	}
+}
+
+// toRGBA64 converts c to color.RGBA64. If c is nil, it returns opaque white.
+func toRGBA64(c color.Color) color.RGBA64 {
+	if c == nil {
+		c = white
+	}
+
+	return color.RGBA64Model.Convert(c).(color.RGBA64) //nolint:forcetypeassert // we know this is a color.RGBA64
+}
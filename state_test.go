@@ -0,0 +1,175 @@
+package twodeeparticles
+
+import (
+	"encoding/json"
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestParticleSystem_Restore(t *testing.T) {
+	is := is.New(t)
+
+	newSystem := func() *ParticleSystem {
+		sys := NewSystem()
+		sys.MaxParticles = 10
+		sys.EmissionRateOverTime = func(d time.Duration, delta time.Duration) float64 {
+			return 5.0
+		}
+		sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+			return 3 * time.Second
+		}
+		sys.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+			return Vector{1, 2}
+		}
+		sys.ColorOverLifetime = func(p *Particle, t NormalizedDuration, delta time.Duration) color.Color {
+			return color.RGBA{0x12, 0x34, 0x56, 0xff}
+		}
+
+		return sys
+	}
+
+	sys := newSystem()
+
+	now := time.Now()
+	sys.Update(now)
+
+	for i := 0; i < 4; i++ {
+		now = now.Add(500 * time.Millisecond)
+		sys.Update(now)
+	}
+
+	state := sys.Snapshot(now)
+
+	is.Equal(state.Duration, 2*time.Second)
+	is.Equal(state.NumEmitted, 10)
+	is.Equal(len(state.Particles), 10)
+	is.Equal(state.Particles[0].Age, 1500*time.Millisecond)
+
+	b, err := json.Marshal(state)
+	is.NoErr(err)
+
+	loaded := SystemState{}
+	is.NoErr(json.Unmarshal(b, &loaded))
+	is.Equal(loaded, state)
+
+	later := now.Add(1 * time.Hour)
+
+	sys2 := newSystem()
+	sys2.Restore(loaded, later)
+
+	is.Equal(sys2.NumParticles(), 10)
+	is.Equal(sys2.NumEmitted(), 10)
+	is.Equal(sys2.Duration(later), 2*time.Second)
+
+	now = now.Add(500 * time.Millisecond)
+	sys.Update(now)
+
+	later = later.Add(500 * time.Millisecond)
+	sys2.Update(later)
+
+	state1 := sys.Snapshot(now)
+	state2 := sys2.Snapshot(later)
+
+	is.Equal(state2.NumEmitted, state1.NumEmitted)
+	is.Equal(len(state2.Particles), len(state1.Particles))
+
+	for idx, p := range state1.Particles {
+		p2 := state2.Particles[idx]
+		is.Equal(p2.Age, p.Age)
+		is.Equal(p2.Seed, p.Seed)
+		is.True(approxEqualVector(p2.Position, p.Position))
+		is.Equal(p2.Color, p.Color)
+	}
+
+	sys2.Spawn(1)
+	sys2.Update(later)
+
+	ids := map[uint64]bool{}
+
+	sys2.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.True(!ids[p.ID()])
+		ids[p.ID()] = true
+	}, later)
+}
+
+func TestParticleSystem_Restore_Pending(t *testing.T) {
+	is := is.New(t)
+
+	newSystem := func() *ParticleSystem {
+		sys := NewSystem()
+		sys.MaxParticles = 100
+		sys.EmissionRateOverTime = func(d time.Duration, delta time.Duration) float64 {
+			return 3.0
+		}
+		sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+			return 10 * time.Second
+		}
+
+		return sys
+	}
+
+	sys := newSystem()
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(500 * time.Millisecond)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 1)
+
+	sys.SpawnBurst(Burst{Count: 5})
+
+	state := sys.Snapshot(now)
+
+	is.Equal(state.ParticlesToEmit, 0.5)
+	is.Equal(state.Bursts, []Burst{{Count: 5}})
+
+	later := now.Add(1 * time.Hour)
+
+	sys2 := newSystem()
+	sys2.Restore(state, later)
+
+	// the fractional particle and the burst are spawned on the next update
+	later = later.Add(500 * time.Millisecond)
+	sys2.Update(later)
+
+	is.Equal(sys2.NumParticles(), 8)
+
+	completed := false
+
+	sys3 := newSystem()
+	sys3.CompleteFunc = func() {
+		completed = true
+	}
+
+	sys2.Stop(StopEmitting)
+
+	state = sys2.Snapshot(later)
+
+	is.True(state.Stopped)
+	is.Equal(state.ParticlesToEmit, 0.0)
+	is.Equal(len(state.Bursts), 0)
+
+	sys3.Restore(state, later)
+	is.True(sys3.Stopped())
+
+	later = later.Add(20 * time.Second)
+	sys3.Update(later)
+
+	is.Equal(sys3.NumParticles(), 0)
+	is.True(completed)
+
+	state = sys3.Snapshot(later)
+	is.True(state.Completed)
+
+	completed = false
+
+	sys3.Restore(state, later)
+	sys3.Update(later.Add(time.Second))
+
+	is.True(!completed)
+}
@@ -0,0 +1,54 @@
+package twodeeparticles
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// An AngleSweep rotates an emission direction over the duration of a system. This can be used to create
+// radar sweep, sprinkler, or spiral galaxy effects.
+type AngleSweep struct {
+	// StartAngle is the emission direction at the start of the system, in radians.
+	StartAngle float64
+
+	// AngularSpeed is the speed at which the emission direction rotates, in radians per second.
+	AngularSpeed float64
+
+	// Arc limits the sweep to an arc starting at StartAngle, in radians. When the end of the arc has been reached,
+	// the emission direction sweeps back to StartAngle, and so on. If Arc is 0, the emission direction rotates
+	// continuously.
+	Arc float64
+
+	// Spread is the width of the cone around the emission direction that particles are emitted into, in radians.
+	Spread float64
+}
+
+// Angle returns the emission direction after duration d has passed, in radians.
+func (s AngleSweep) Angle(d time.Duration) float64 {
+	a := s.AngularSpeed * d.Seconds()
+
+	if s.Arc > 0 {
+		a = math.Mod(math.Abs(a), 2.0*s.Arc)
+		if a > s.Arc {
+			a = 2.0*s.Arc - a
+		}
+
+		if s.AngularSpeed < 0 {
+			a = -a
+		}
+	}
+
+	return s.StartAngle + a
+}
+
+// Direction returns a unit vector pointing in the emission direction after duration d has passed.
+func (s AngleSweep) Direction(d time.Duration) Vector {
+	return VectorFromAngle(s.Angle(d))
+}
+
+// VelocityOverTime returns a function that can be used as ParticleSystem.InitialVelocityOverTime. Particles will be
+// emitted into the cone around the emission direction, with the given speed.
+func (s AngleSweep) VelocityOverTime(speed float64, rand *rand.Rand) SpawnVectorOverTimeFunc {
+	return VelocityFromPattern(s, speed, s.Spread, rand)
+}
@@ -0,0 +1,71 @@
+package twodeeparticles
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestAngleSweep_Angle(t *testing.T) {
+	is := is.New(t)
+
+	s := AngleSweep{
+		StartAngle:   0.5,
+		AngularSpeed: math.Pi / 2.0,
+	}
+
+	is.True(approxEqual(s.Angle(0), 0.5))
+	is.True(approxEqual(s.Angle(1*time.Second), 0.5+math.Pi/2.0))
+	is.True(approxEqual(s.Angle(5*time.Second), 0.5+5.0*math.Pi/2.0))
+
+	s.Arc = math.Pi / 2.0
+
+	is.True(approxEqual(s.Angle(1*time.Second), 0.5+math.Pi/2.0))
+	is.True(approxEqual(s.Angle(1500*time.Millisecond), 0.5+math.Pi/4.0))
+	is.True(approxEqual(s.Angle(2*time.Second), 0.5))
+	is.True(approxEqual(s.Angle(2500*time.Millisecond), 0.5+math.Pi/4.0))
+
+	s.AngularSpeed = -s.AngularSpeed
+
+	is.True(approxEqual(s.Angle(1*time.Second), 0.5-math.Pi/2.0))
+	is.True(approxEqual(s.Angle(1500*time.Millisecond), 0.5-math.Pi/4.0))
+}
+
+func TestAngleSweep_Direction(t *testing.T) {
+	is := is.New(t)
+
+	s := AngleSweep{
+		AngularSpeed: math.Pi / 2.0,
+	}
+
+	dir := s.Direction(1 * time.Second)
+	is.True(approxEqual(dir.X, 0.0))
+	is.True(approxEqual(dir.Y, 1.0))
+}
+
+func TestAngleSweep_VelocityOverTime(t *testing.T) {
+	is := is.New(t)
+
+	s := AngleSweep{
+		AngularSpeed: math.Pi / 2.0,
+		Spread:       math.Pi / 4.0,
+	}
+
+	fun := s.VelocityOverTime(10.0, rand.New(rand.NewSource(1))) //nolint:gosec // no crypto
+
+	for i := 0; i < 100; i++ {
+		v := fun(1*time.Second, 0, ZeroVector)
+		is.True(approxEqual(v.Magnitude(), 10.0))
+
+		a := math.Atan2(v.Y, v.X)
+		is.True(a >= math.Pi/2.0-math.Pi/8.0-1e-9)
+		is.True(a <= math.Pi/2.0+math.Pi/8.0+1e-9)
+	}
+}
+
+func approxEqual(a float64, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
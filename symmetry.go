@@ -0,0 +1,100 @@
+package twodeeparticles
+
+import "math"
+
+// SymmetryMode specifies how copies of a spawned particle are arranged around the system's origin.
+type SymmetryMode int
+
+// A Symmetry spawns additional copies of each particle that is being spawned, arranged symmetrically around
+// the system's origin. This can be used to create kaleidoscope-like effects, such as mandalas or magic circles.
+type Symmetry struct {
+	// Mode specifies how copies are arranged.
+	Mode SymmetryMode
+
+	// Copies is the total number of particles spawned at a time, including the original particle,
+	// when using SymmetryRotate.
+	Copies int
+}
+
+const (
+	// SymmetryNone does not spawn any copies.
+	SymmetryNone SymmetryMode = iota
+
+	// SymmetryRotate spawns copies that are rotated around the system's origin by equal angles (360/Copies degrees.)
+	SymmetryRotate
+
+	// SymmetryMirrorX spawns a copy that is reflected across the X axis.
+	SymmetryMirrorX
+
+	// SymmetryMirrorY spawns a copy that is reflected across the Y axis.
+	SymmetryMirrorY
+
+	// SymmetryMirrorXY spawns three copies that are reflected across the X axis, the Y axis, and both axes.
+	SymmetryMirrorXY
+)
+
+func (s Symmetry) count() int {
+	switch s.Mode {
+	case SymmetryRotate:
+		if s.Copies < 1 {
+			return 1
+		}
+
+		return s.Copies
+
+	case SymmetryMirrorX, SymmetryMirrorY:
+		return 2
+
+	case SymmetryMirrorXY:
+		return 4
+
+	default:
+		return 1
+	}
+}
+
+// angle returns the rotation angle of copy i.
+func (s Symmetry) angle(i int) float64 {
+	if s.Mode != SymmetryRotate {
+		return 0.0
+	}
+
+	return 2.0 * math.Pi * float64(i) / float64(s.count())
+}
+
+// spin returns the angular velocity w as seen by copy i. Reflected copies spin in the opposite direction.
+func (s Symmetry) spin(i int, w float64) float64 {
+	switch {
+	case s.Mode == SymmetryMirrorX, s.Mode == SymmetryMirrorY, s.Mode == SymmetryMirrorXY && i < 3:
+		return -w
+	default:
+		return w
+	}
+}
+
+// transform returns v as seen by copy i.
+func (s Symmetry) transform(i int, v Vector) Vector {
+	switch s.Mode {
+	case SymmetryRotate:
+		return v.Rotate(s.angle(i))
+
+	case SymmetryMirrorX:
+		return Vector{v.X, -v.Y}
+
+	case SymmetryMirrorY:
+		return Vector{-v.X, v.Y}
+
+	case SymmetryMirrorXY:
+		switch i {
+		case 1:
+			return Vector{v.X, -v.Y}
+		case 2:
+			return Vector{-v.X, v.Y}
+		default:
+			return Vector{-v.X, -v.Y}
+		}
+
+	default:
+		return v
+	}
+}
@@ -0,0 +1,107 @@
+package twodeeparticles
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestSymmetry_Rotate(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+
+	sys.MaxParticles = 10
+
+	sys.EmissionSymmetry = Symmetry{
+		Mode:   SymmetryRotate,
+		Copies: 4,
+	}
+
+	sys.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) Vector {
+		return Vector{10, 0}
+	}
+
+	sys.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+		return Vector{0, 5}
+	}
+
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 4)
+
+	var (
+		positions  []Vector
+		velocities []Vector
+		angles     []float64
+	)
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		positions = append(positions, p.Position())
+		velocities = append(velocities, p.Velocity())
+		angles = append(angles, p.Angle())
+	}, now)
+
+	is.True(approxEqualVector(positions[1], Vector{0, 10}))
+	is.True(approxEqualVector(positions[2], Vector{-10, 0}))
+	is.True(approxEqualVector(positions[3], Vector{0, -10}))
+	is.True(approxEqualVector(velocities[1], Vector{-5, 0}))
+	is.True(approxEqual(angles[0], 0.0))
+	is.True(approxEqual(angles[2], math.Pi))
+}
+
+func TestSymmetry_MirrorXY(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+
+	sys.MaxParticles = 10
+
+	sys.EmissionSymmetry = Symmetry{
+		Mode: SymmetryMirrorXY,
+	}
+
+	sys.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) Vector {
+		return Vector{3, 5}
+	}
+
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	var positions []Vector
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		positions = append(positions, p.Position())
+	}, now)
+
+	is.Equal(positions, []Vector{{3, 5}, {3, -5}, {-3, 5}, {-3, -5}})
+}
+
+func TestSymmetry_MaxParticles(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+
+	sys.MaxParticles = 3
+
+	sys.EmissionSymmetry = Symmetry{
+		Mode:   SymmetryRotate,
+		Copies: 5,
+	}
+
+	sys.Spawn(1)
+	sys.Update(time.Now())
+
+	is.Equal(sys.NumParticles(), 3)
+}
+
+func approxEqualVector(v1 Vector, v2 Vector) bool {
+	return approxEqual(v1.X, v2.X) && approxEqual(v1.Y, v2.Y)
+}
@@ -2,6 +2,8 @@ package twodeeparticles
 
 import (
 	"image/color"
+	"math"
+	"math/rand"
 	"sync"
 	"time"
 )
@@ -11,11 +13,22 @@ import (
 // The position of a particle is always relative to its system's origin. In other words, a particle system maintains its
 // own frame of reference. Particles are not simulated in "world space." However, when particles are actually drawn on screen,
 // the origin of the particle system can be moved freely, thus emulating a simulation in world space.
+//
+// Particles are always kept in the order they have been spawned, oldest first. A respawned particle (see RespawnMode)
+// counts as newly spawned. Renderers can rely on this order, for example to draw newer particles on top of older ones.
 type ParticleSystem struct {
 	// MaxParticles limits the total number of particles being alive at a time. When particles die, new particles may be
-	// spawned according to EmissionRateOverTime.
+	// spawned according to EmissionRateOverTime. The system preallocates MaxParticles particles on the first Update,
+	// and reuses particles after they have died.
 	MaxParticles int
 
+	// CullOldestAfter makes room for new particles when the system has been saturated for too long. If the number
+	// of alive particles has been at MaxParticles for at least CullOldestAfter while new particles were waiting to be
+	// spawned, the oldest particles are killed to make room for the new ones.
+	//
+	// If CullOldestAfter is 0, particles will not be culled.
+	CullOldestAfter time.Duration
+
 	// DataOverLifetime returns arbitrary data for a particle, over its lifetime. This allows to attach data to the particle
 	// and act on it later on. The data returned is not used by the system itself.
 	DataOverLifetime ParticleDataOverNormalizedTimeFunc
@@ -33,6 +46,42 @@ type ParticleSystem struct {
 	// If EmissionRateOverTime is nil, no particles will spawn.
 	EmissionRateOverTime ValueOverTimeFunc
 
+	// TotalEmissionLimit limits the total number of particles that are spawned over the lifetime of the system,
+	// including particles spawned using Spawn or SpawnBurst, and symmetry copies. Respawned particles (see RespawnMode)
+	// are not counted. When the limit has been reached, the system stops spawning particles, while the remaining
+	// particles live on. This is useful for one-shot effects that are driven by EmissionRateOverTime. Reset resets
+	// the count.
+	//
+	// If TotalEmissionLimit is 0, the number of particles spawned is not limited.
+	TotalEmissionLimit int
+
+	// EmissionDuration limits how long the system spawns particles according to EmissionRateOverTime. After
+	// EmissionDuration has passed, the system stops spawning particles, while the remaining particles live on.
+	// Particles spawned using Spawn or SpawnBurst are not affected.
+	//
+	// If Looping is set, the system does not stop spawning particles. Instead, the duration that is passed to
+	// EmissionRateOverTime and the other functions that are called for particles being spawned (such as
+	// EmissionPositionOverTime) starts over at 0 after each EmissionDuration.
+	//
+	// If EmissionDuration is 0, the system spawns particles indefinitely.
+	EmissionDuration time.Duration
+
+	// Looping makes the system repeat its emission every EmissionDuration (see EmissionDuration.)
+	Looping bool
+
+	// CompleteFunc is called during Update once the system has completed, that is, when EmissionDuration has passed
+	// without Looping or the system has been stopped (see Stop), and all remaining particles have died. It is called
+	// only once, until the system is Reset. This can be used to remove one-shot effects when they are finished.
+	CompleteFunc func()
+
+	// EmissionGate is called during Update before particles are spawned according to EmissionRateOverTime. If it
+	// returns false, no particles are spawned, and the emission rate does not accumulate. This can be used to tie
+	// emission to game state, for example to emit particles only while a button is being held. Particles spawned
+	// using Spawn or SpawnBurst are not affected.
+	//
+	// If EmissionGate is nil, particles are always spawned.
+	EmissionGate func() bool
+
 	// EmissionPositionOverTime returns the initial position of a particle that is being spawned, over the duration
 	// of the system. The position is measured in arbitrary units (for example, in pixels), and is relative to the
 	// system's origin.
@@ -40,41 +89,362 @@ type ParticleSystem struct {
 	// If EmissionPositionOverTime is nil, particles will spawn at the origin.
 	EmissionPositionOverTime VectorOverTimeFunc
 
+	// EmissionBounds constrains the initial positions of particles being spawned to a region, relative to the system's
+	// origin. This prevents effects from leaking out of the intended area when EmissionPositionOverTime is only
+	// an approximation, for example to emit bubbles inside of a body of water only. If a position is outside
+	// of EmissionBounds, EmissionPositionOverTime is called again, up to EmissionResamples times. If the position is
+	// still outside, the particle is handled according to EmissionBoundsMode. Symmetry copies (see EmissionSymmetry)
+	// and particles respawned in place (see RespawnInPlace) are not constrained.
+	//
+	// If EmissionBounds is nil, initial positions are not constrained.
+	EmissionBounds Shape
+
+	// EmissionResamples is the maximum number of times EmissionPositionOverTime is called again for a particle whose
+	// initial position is outside of EmissionBounds.
+	EmissionResamples int
+
+	// EmissionBoundsMode specifies what happens to a particle whose initial position is outside of EmissionBounds
+	// after all resamples have been used up.
+	EmissionBoundsMode EmissionBoundsMode
+
+	// InitialVelocityOverTime returns the initial velocity (direction times speed) of a particle that is being spawned
+	// at spawnPos, in arbitrary units per second, over the duration of the system. It is called exactly once for each
+	// particle, so there is no need to initialize the velocity in VelocityOverLifetime.
+	//
+	// If InitialVelocityOverTime is nil, particles will spawn with a velocity of zero.
+	InitialVelocityOverTime SpawnVectorOverTimeFunc
+
+	// EmissionSymmetry spawns additional copies of each particle that is being spawned, arranged symmetrically
+	// around the system's origin. Copies count towards MaxParticles. The initial position, initial velocity,
+	// and angle of a copy are transformed according to the symmetry.
+	EmissionSymmetry Symmetry
+
+	// WrapBounds wraps the positions of particles around a rectangle, relative to the system's origin. Particles leaving
+	// the rectangle on one side re-enter it on the opposite side, instead of moving on. This is useful for screen-looping
+	// ambient effects.
+	//
+	// If WrapBounds has a width or height of zero, positions will not be wrapped along that axis.
+	WrapBounds Rect
+
+	// WrapQueries makes spatial queries such as ApplyImpulse also wrap around WrapBounds. For example, an impulse
+	// applied close to the right edge of WrapBounds will then also affect particles close to the left edge.
+	WrapQueries bool
+
+	// AmbientVolumeFunc returns a rectangle that follows the camera, relative to the system's origin (for example,
+	// the part of the level that is currently visible.) It is called at the start of each Update. If it is set,
+	// the system runs in ambient mode: Particles are spawned at random positions inside of the rectangle, instead of
+	// at positions returned by EmissionPositionOverTime. Particles leaving the rectangle (because they move, or
+	// because the camera moves) re-enter it on the opposite side, as with WrapBounds. This way, effects such as dust
+	// or snow can fill the whole screen and follow the camera, without having to emit particles across the entire
+	// level.
+	//
+	// If AmbientVolumeFunc is set, WrapBounds is not used to wrap the positions of particles. It is still used
+	// by WrapQueries.
+	AmbientVolumeFunc func() Rect
+
+	// Targets are points that modules such as Seek can reference by index. The positions are relative to the system's
+	// origin. Targets can be changed at any time, for example on every frame to follow the bones of an animated character.
+	Targets []Vector
+
+	// RespawnMode specifies what happens to particles when they die. Respawning particles instead of removing them
+	// keeps the number of particles constant, which is useful for looping ambient effects such as starfields.
+	// DeathFunc is called for respawned particles as usual.
+	RespawnMode RespawnMode
+
+	// SubFrameEmission spreads the birth times of particles spawned during a single Update evenly across the duration
+	// since the last update, instead of spawning all of them at the same time. Each particle is updated at its birth
+	// time first, and is then moved forward to the current time along with all other particles. This prevents visible
+	// clumps of particles when using high emission rates at low frame rates.
+	SubFrameEmission bool
+
+	// Prewarm makes the first Update fast-forward the system by Prewarm, as if the system had already been running
+	// for that long. This lets ambient effects such as rain or smoke appear in a steady state on the first visible
+	// frame, instead of ramping up from zero particles. During the first Update, the system is simulated in steps of
+	// PrewarmStep, and all callbacks are called as usual. The duration of the system (see Duration) includes Prewarm.
+	//
+	// If Prewarm is 0, the system is not prewarmed.
+	Prewarm time.Duration
+
+	// PrewarmStep is the duration of a single simulation step while prewarming (see Prewarm.) Smaller steps are more
+	// accurate, but take more time.
+	//
+	// If PrewarmStep is 0, a step of 1/30 second is used.
+	PrewarmStep time.Duration
+
+	// Culled pauses the simulation while the system is not visible (for example, because it is off-screen), to save
+	// CPU time. While Culled is set, Update only records the time that has passed. When Culled is unset again,
+	// the next Update fast-forwards the system to the current time in steps of CatchUpStep, so that ambient effects
+	// stay consistent with world time. All callbacks are called as usual while catching up.
+	Culled bool
+
+	// CatchUpStep is the duration of a single simulation step while catching up after the system has been culled
+	// (see Culled.)
+	//
+	// If CatchUpStep is 0, a step of 1/30 second is used.
+	CatchUpStep time.Duration
+
+	// MaxCatchUp limits the time that is simulated while catching up after the system has been culled (see Culled.)
+	// If the system has been culled for longer than MaxCatchUp, all particles are killed, and only the last MaxCatchUp
+	// is simulated. To keep ambient effects consistent, MaxCatchUp should be at least as long as the lifetime
+	// of particles.
+	//
+	// If MaxCatchUp is 0, the time simulated is not limited.
+	MaxCatchUp time.Duration
+
 	// LifetimeOverTime returns the lifetime of a particle that is being spawned, over the duration of the system.
 	// After the duration has passed, the particle will die automatically.
 	//
+	// To spawn particles that never die automatically, return InfiniteLifetime.
+	//
 	// If LifetimeOverTime is nil, particles will die after 1 second.
 	LifetimeOverTime DurationOverTimeFunc
 
 	// VelocityOverLifetime returns a particle's velocity (direction times speed), in arbitrary units per second,
 	// over its lifetime.
 	//
-	// If VelocityOverLifetime is nil, particles will not move.
+	// If VelocityOverLifetime is nil, particles will move according to VelocityXOverLifetime and VelocityYOverLifetime,
+	// or SpeedOverLifetime and DirectionOverLifetime. If those are nil as well, particles will keep moving with their
+	// initial velocity (see InitialVelocityOverTime.)
+	//
+	// Velocity changes made between updates (for example, by ApplyImpulse, Separation, or Attractors) only have
+	// a lasting effect if VelocityOverLifetime takes the particles' current velocities into account (see
+	// Particle.Velocity), or if it is nil. Otherwise, they are overwritten on the next update.
 	VelocityOverLifetime ParticleVectorOverNormalizedTimeFunc
 
+	// VelocityXOverLifetime returns the X component of a particle's velocity, in arbitrary units per second, over its
+	// lifetime. It is only used if VelocityOverLifetime is nil.
+	//
+	// If VelocityXOverLifetime is nil, particles will keep the X component of their current velocity.
+	VelocityXOverLifetime ParticleValueOverNormalizedTimeFunc
+
+	// VelocityYOverLifetime returns the Y component of a particle's velocity, in arbitrary units per second, over its
+	// lifetime. It is only used if VelocityOverLifetime is nil.
+	//
+	// If VelocityYOverLifetime is nil, particles will keep the Y component of their current velocity.
+	VelocityYOverLifetime ParticleValueOverNormalizedTimeFunc
+
+	// SpeedOverLifetime returns a particle's speed, in arbitrary units per second, over its lifetime. It is only used
+	// if VelocityOverLifetime, VelocityXOverLifetime, and VelocityYOverLifetime are nil.
+	//
+	// If SpeedOverLifetime is nil, particles will keep their current speed.
+	SpeedOverLifetime ParticleValueOverNormalizedTimeFunc
+
+	// DirectionOverLifetime returns a particle's direction of movement, in radians, over its lifetime. It is only used
+	// if VelocityOverLifetime, VelocityXOverLifetime, and VelocityYOverLifetime are nil.
+	//
+	// If DirectionOverLifetime is nil, particles will keep their current direction.
+	DirectionOverLifetime ParticleValueOverNormalizedTimeFunc
+
+	// AccelerationOverLifetime returns a particle's acceleration, in arbitrary units per second squared, over its
+	// lifetime. The acceleration is added to the particle's velocity after VelocityOverLifetime, SpeedOverLifetime,
+	// and DirectionOverLifetime have been applied. This can be used to apply forces such as gravity or wind.
+	//
+	// If AccelerationOverLifetime is nil, particles will not accelerate.
+	AccelerationOverLifetime ParticleVectorOverNormalizedTimeFunc
+
+	// Oscillations are added to the positions or velocities of particles. Oscillations are based on particles' ages,
+	// and are applied after AccelerationOverLifetime. Multiple oscillations can be combined, for example to make
+	// particles move in circles.
+	Oscillations []Oscillation
+
+	// Separation pushes particles apart that are closer to each other than a radius. The velocity changes are applied
+	// at the start of each Update, and are kept according to VelocityOverLifetime.
+	Separation Separation
+
+	// Fluid makes particles behave like a simplified fluid. The velocity changes are applied at the start of each
+	// Update, after Separation. Fluid relies on velocities carrying over between updates (see VelocityOverLifetime.)
+	Fluid Fluid
+
+	// Merge combines particles that are close to each other into bigger particles. Particles are merged at the start
+	// of each Update, after Separation and Fluid have been applied. The merged scales and colors are replaced by
+	// ScaleOverLifetime and ColorOverLifetime on the next update, unless those build on particles' current values.
+	Merge Merge
+
+	// Attractors pull particles towards positions that are queried on every Update. The velocity changes are applied
+	// at the start of each Update, before Separation. Their pull only accumulates over time if VelocityOverLifetime
+	// allows it.
+	Attractors []InteractiveAttractor
+
+	// DensityRadius enables computing the local density of particles around each particle (see Particle.Density.)
+	// The density is computed on every Update, after new particles have been spawned, and before particles are updated.
+	// This can be used to make dense regions of an effect look thicker (see OpacityFromDensity.)
+	//
+	// If DensityRadius is 0, densities are not computed.
+	DensityRadius float64
+
 	// ScaleOverLifetime returns a particle's scale (size multiplier), over its lifetime.
 	//
 	// If ScaleOverLifetime is nil, particles will use (1.0,1.0).
 	ScaleOverLifetime ParticleVectorOverNormalizedTimeFunc
 
+	// PivotOverLifetime returns a particle's pivot, over its lifetime. The pivot is the point of a particle's sprite
+	// that is placed at the particle's position, and that the sprite is rotated and scaled around. It is relative to
+	// the sprite's center, in unscaled sprite units (for example, in pixels.) For example, grass blades can be rotated
+	// around their base by using a pivot at the bottom of the sprite. The pivot is not used by the simulation itself,
+	// only by renderers (see RenderParticle.Pivot.)
+	//
+	// If PivotOverLifetime is nil, particles will use (0.0,0.0), that is, the center of the sprite.
+	PivotOverLifetime ParticleVectorOverNormalizedTimeFunc
+
+	// SkewOverLifetime returns a particle's skew (shear), over its lifetime. X and Y are the skew angles along the X
+	// and Y axes, in radians. The sprite is skewed around its pivot (see PivotOverLifetime), after it has been scaled,
+	// but before it is rotated. For example, grass blades or flames can lean in the wind by skewing them along
+	// the X axis. The skew is not used by the simulation itself, only by renderers (see RenderParticle.Skew.)
+	//
+	// If SkewOverLifetime is nil, particles will use (0.0,0.0), that is, no skew.
+	SkewOverLifetime ParticleVectorOverNormalizedTimeFunc
+
 	// ColorOverLifetime returns a particle's color, over its lifetime.
 	//
 	// If ColorOverLifetime is nil, particles will use color.White.
 	ColorOverLifetime ParticleColorOverNormalizedTimeFunc
 
-	// RotationOverLifetime returns a particle's angular velocity, in radians, over its lifetime.
+	// VisibilityOverLifetime returns whether a particle is visible, over its lifetime. Invisible particles are still
+	// simulated as usual, but should not be drawn. This can be used for blinking effects such as fireflies
+	// (see DutyCycle.)
+	//
+	// If VisibilityOverLifetime is nil, particles are always visible.
+	VisibilityOverLifetime ParticleBoolOverNormalizedTimeFunc
+
+	// InitialAngularVelocityOverTime returns the initial angular velocity of a particle, in radians per second,
+	// over the duration of the system.
+	//
+	// If InitialAngularVelocityOverTime is nil, particles will start with an angular velocity of 0.
+	InitialAngularVelocityOverTime ValueOverTimeFunc
+
+	// RotationOverLifetime returns a particle's angular velocity, in radians per second, over its lifetime.
 	//
-	// If RotationOverLifetime is nil, particles will not rotate.
+	// If RotationOverLifetime is nil, particles will keep their current angular velocity.
 	RotationOverLifetime ParticleValueOverNormalizedTimeFunc
 
-	initOnce        sync.Once
-	particles       []*Particle
-	pool            sync.Pool
-	startTime       time.Time
-	lastUpdateTime  time.Time
-	particlesToEmit float64
+	// AngularAccelerationOverLifetime returns a particle's angular acceleration, in radians per second squared, over its
+	// lifetime. The angular acceleration is added to the particle's angular velocity after RotationOverLifetime has been
+	// applied.
+	//
+	// If AngularAccelerationOverLifetime is nil, particles will not accelerate their rotation.
+	AngularAccelerationOverLifetime ParticleValueOverNormalizedTimeFunc
+
+	// SpinDamping is the rate at which particles' angular velocities decay, per second. The angular velocity is
+	// multiplied by e^(-SpinDamping*t) after t seconds, so that tumbling debris gradually stops spinning.
+	// Spin damping is applied after AngularAccelerationOverLifetime.
+	//
+	// If SpinDamping is 0, angular velocities do not decay.
+	SpinDamping float64
+
+	// Instances are additional origins that the system's particles should be drawn at, relative to the system's origin.
+	// This allows a single simulation to be shown at many places, for example for 50 identical torches, which saves
+	// a lot of CPU time. Instances are only used when rendering (see RenderSnapshot.ForEachInstance), and do not
+	// affect the simulation.
+	//
+	// If Instances is empty, particles are drawn once, at the system's origin.
+	Instances []Vector
+
+	// Deterministic makes the system produce the same results when it is run repeatedly with the same inputs. This is
+	// required for lockstep multiplayer games that use particles for gameplay purposes (for example, for damaging
+	// embers.) If Deterministic is set, particle seeds (see Particle.Random) are derived from particle IDs instead of
+	// being random. Particles are always updated in ID order, and their floating-point computations are always
+	// performed in the same order.
+	//
+	// Deterministic results also require that:
+	//
+	//   - Update is called with the same sequence of timestamps. The timestamps should be derived from a simulation
+	//     clock, not from time.Now.
+	//   - All callbacks are deterministic. Helpers of this package that accept a *rand.Rand must be passed
+	//     a *rand.Rand with a fixed seed that is not shared with code outside of the system, such as the one
+	//     returned by Rand (see SetSeed.)
+	//   - Reset is called, or a new system is created, before each run.
+	//
+	// Results are only guaranteed to be identical on the same platform. The Go compiler may fuse floating-point
+	// multiplications and additions on some architectures (for example, arm64), which can cause tiny differences
+	// between platforms that grow over time.
+	Deterministic bool
+
+	// FixedPoint makes particles' positions and velocities be integrated using fixed-point integer math, instead of
+	// floating-point math. Positions and velocities are rounded to multiples of 1/65536, and are limited to about
+	// ±6.8e10. This trades precision for bit-identical integration results across platforms, which Deterministic alone
+	// does not guarantee. FixedPoint should be combined with Deterministic.
+	//
+	// Values returned by callbacks are still computed using floating-point math, and are rounded to the fixed-point
+	// grid afterwards. For cross-platform results, callbacks must avoid floating-point computations that may differ
+	// between platforms, such as fused multiply-add operations, and functions of package math such as math.Sin (which
+	// are used by helpers such as Oscillation.)
+	FixedPoint bool
+
+	// Debug enables additional checks that detect incorrect use of the system. Currently, it detects when functions
+	// called during Update or UpdateBudget (such as UpdateFunc) change MaxParticles or assign new functions to the
+	// system's fields, which causes undefined behavior. Such changes should only be made between updates (see
+	// Configure.) Debug slows down Update, so it should only be used during development.
+	Debug bool
+
+	// DebugFunc is called with an error when Debug is set and incorrect use of the system has been detected.
+	//
+	// If DebugFunc is nil, the system panics instead.
+	DebugFunc func(err error)
+
+	initOnce           sync.Once
+	particles          []*Particle
+	free               []*Particle
+	respawned          []*Particle
+	startTime          time.Time
+	lastUpdateTime     time.Time
+	particlesToEmit    float64
+	numEmitted         int
+	lastID             uint64
+	bursts             []Burst
+	killPredicates     []ParticlePredicateFunc
+	grid               spatialGrid
+	fluidAccelerations []Vector
+	saturatedSince     time.Time
+	iterating          int
+	deferred           []func()
+	periodicFuncs      []*periodicFunc
+	budgetLastID       uint64
+	completed          bool
+	wasCulled          bool
+	debugFingerprint   *configFingerprint
+	stopped            bool
+	clock              virtualClock
+	configureMutex     sync.Mutex
+	configureFuncs     []ConfigureFunc
+	seed               uint64
+	seeded             bool
+	rand               *rand.Rand
+	randSet            bool
+	view               viewTransform
+	ambientVolume      Rect
 }
 
+// defaultStep is the duration of a single simulation step while prewarming or catching up, if PrewarmStep
+// or CatchUpStep is 0, respectively.
+const defaultStep = time.Second / 30
+
+// RespawnMode specifies what happens to particles when they die.
+type RespawnMode int
+
+const (
+	// RespawnNone removes dying particles from the system.
+	RespawnNone RespawnMode = iota
+
+	// RespawnAtEmissionPosition immediately respawns dying particles, at a new position returned by
+	// EmissionPositionOverTime.
+	RespawnAtEmissionPosition
+
+	// RespawnInPlace immediately respawns dying particles, at the position they have died at.
+	RespawnInPlace
+)
+
+// StopMode specifies how a system is stopped (see ParticleSystem.Stop.)
+type StopMode int
+
+const (
+	// StopEmitting stops spawning particles according to EmissionRateOverTime, and stops respawning particles
+	// (see RespawnMode.) Alive particles live on until they die.
+	StopEmitting StopMode = iota
+
+	// StopAndClear stops spawning particles like StopEmitting, and immediately kills all alive particles.
+	StopAndClear
+)
+
 // ParticleDeathFunc is a function that is called when p has died.
 type ParticleDeathFunc func(p *Particle)
 
@@ -86,6 +456,11 @@ type ValueOverTimeFunc func(d time.Duration, delta time.Duration) float64
 // delta is the duration since the last update (for example, the duration since the last GPU frame.)
 type VectorOverTimeFunc func(d time.Duration, delta time.Duration) Vector
 
+// SpawnVectorOverTimeFunc is a function that returns a vector for a particle that is being spawned at spawnPos,
+// after duration d has passed. delta is the duration since the last update (for example, the duration since
+// the last GPU frame.)
+type SpawnVectorOverTimeFunc func(d time.Duration, delta time.Duration, spawnPos Vector) Vector
+
 // DurationOverTimeFunc is a function that returns a duration after duration d has passed.
 // delta is the duration since the last update (for example, the duration since the last GPU frame.)
 type DurationOverTimeFunc func(d time.Duration, delta time.Duration) time.Duration
@@ -98,6 +473,10 @@ type ParticleValueOverNormalizedTimeFunc func(p *Particle, t NormalizedDuration,
 // delta is the duration since the last update (for example, the duration since the last GPU frame.)
 type ParticleVectorOverNormalizedTimeFunc func(p *Particle, t NormalizedDuration, delta time.Duration) Vector
 
+// ParticleBoolOverNormalizedTimeFunc is a function that returns a boolean for p after p's duration t has passed.
+// delta is the duration since the last update (for example, the duration since the last GPU frame.)
+type ParticleBoolOverNormalizedTimeFunc func(p *Particle, t NormalizedDuration, delta time.Duration) bool
+
 // ParticleColorOverNormalizedTimeFunc is a function that returns a color for p after p's duration t has passed.
 // delta is the duration since the last update (for example, the duration since the last GPU frame.)
 type ParticleColorOverNormalizedTimeFunc func(p *Particle, t NormalizedDuration, delta time.Duration) color.Color
@@ -113,89 +492,391 @@ type ParticleDataOverNormalizedTimeFunc func(old any, t NormalizedDuration, delt
 // the duration since the last GPU frame.)
 type ParticleVisitFunc func(p *Particle, t NormalizedDuration, delta time.Duration)
 
+// ParticlePredicateFunc is a function that returns whether p matches a condition.
+type ParticlePredicateFunc func(p *Particle) bool
+
 // NormalizedDuration is a normalized duration during a longer duration (for example, during a particle's lifetime.)
 // The value is always in the range [0.0,1.0], with 0.0 being the start of the longer duration and 1.0 being the end
-// of the longer duration.
+// of the longer duration. For particles with an infinite lifetime, the value is always 0.0. For particles with
+// a lifetime of zero or less, the value is always 1.0.
 type NormalizedDuration float64
 
 // NewSystem returns a new particle system.
 func NewSystem() *ParticleSystem {
-	sys := &ParticleSystem{
+	return &ParticleSystem{
 		initOnce: sync.Once{},
-		pool:     sync.Pool{},
+	}
+}
+
+// Update updates the system. now should usually be time.Now(). The system converts now into its own simulation time,
+// which does not advance while the system is paused (see Pause.)
+//
+// If Update is called while the system's particles are being iterated over (for example, from a function passed
+// to ForEachParticle), the update is deferred until the iteration has finished.
+func (sys *ParticleSystem) Update(now time.Time) {
+	if sys.deferIfIterating(func() { sys.Update(now) }) {
+		return
 	}
 
-	sys.pool.New = func() any {
-		return newParticle(sys)
+	now, ok := sys.prepareUpdate(now, true)
+	if !ok {
+		return
 	}
 
-	return sys
+	defer sys.finishUpdate()
+
+	sys.update(now)
 }
 
-// Update updates the system. now should usually be time.Now().
-func (sys *ParticleSystem) Update(now time.Time) {
+// prepareUpdate performs the work that is common to Update and UpdateBudget before particles are updated.
+// roundStart is whether a new update round is starting. It returns the simulation time at now, and whether
+// particles should be updated at all, which is not the case while the system is paused or culled. If particles
+// should be updated, the caller must call finishUpdate when done.
+func (sys *ParticleSystem) prepareUpdate(now time.Time, roundStart bool) (time.Time, bool) {
+	if roundStart {
+		sys.applyConfigure()
+	}
+
 	sys.initOnce.Do(func() {
 		sys.init(now)
 	})
 
+	now = sys.clock.advance(now)
+	if sys.clock.paused {
+		return now, false
+	}
+
+	if sys.Culled {
+		sys.wasCulled = true
+		return now, false
+	}
+
+	if sys.Debug {
+		fp := sys.fingerprint()
+		sys.debugFingerprint = &fp
+	}
+
+	if sys.wasCulled {
+		sys.catchUp(now)
+	}
+
+	return now, true
+}
+
+// update performs a single simulation step up to now.
+func (sys *ParticleSystem) update(now time.Time) {
 	defer func() {
 		sys.lastUpdateTime = now
 	}()
 
+	sys.budgetLastID = 0
+	sys.beforeUpdate(now)
+
 	for {
-		sys.removeDeadParticles(now)
+		sys.removeDeadParticles(now, !sys.stopped)
 		sys.spawnParticles(now)
+		sys.computeDensities()
 
-		if !sys.updateParticles(now) {
-			break
+		morePasses, dead := sys.updateParticles(now)
+		if morePasses {
+			continue
 		}
+
+		if dead {
+			sys.removeDeadParticles(now, !sys.stopped)
+		}
+
+		break
 	}
+
+	sys.checkComplete(now)
+}
+
+// beforeUpdate performs all work at the start of an update that affects the system as a whole.
+func (sys *ParticleSystem) beforeUpdate(now time.Time) {
+	if sys.AmbientVolumeFunc != nil {
+		sys.ambientVolume = sys.AmbientVolumeFunc()
+	}
+
+	sys.runPeriodicFuncs(now)
+	sys.applyKillPredicates()
+	sys.applyAttractors(now)
+	sys.applySeparation(now)
+	sys.applyFluid(now)
+	sys.applyMerge()
 }
 
 func (sys *ParticleSystem) init(now time.Time) {
-	sys.startTime = now
-	sys.lastUpdateTime = now
+	sys.clock.init(now)
+
+	start := now.Add(-sys.Prewarm)
+
+	sys.startTime = start
+	sys.lastUpdateTime = start
+
+	sys.preallocateParticles()
+
+	if sys.Prewarm > 0 {
+		sys.simulate(start, now, sys.PrewarmStep)
+	}
 }
 
-func (sys *ParticleSystem) removeDeadParticles(now time.Time) {
-	for idx := len(sys.particles) - 1; idx >= 0; idx-- {
-		part := sys.particles[idx]
+// catchUp fast-forwards the system to now after it has been culled.
+func (sys *ParticleSystem) catchUp(now time.Time) {
+	sys.wasCulled = false
+
+	if sys.MaxCatchUp > 0 && now.Sub(sys.lastUpdateTime) > sys.MaxCatchUp {
+		for _, p := range sys.particles {
+			p.Kill()
+		}
+
+		sys.removeDeadParticles(now, false)
+
+		sys.particlesToEmit = 0.0
+		sys.lastUpdateTime = now.Add(-sys.MaxCatchUp)
+	}
+
+	sys.simulate(sys.lastUpdateTime, now, sys.CatchUpStep)
+}
+
+// simulate simulates the system from start up to (but not including) now, in steps of step. If step is 0,
+// defaultStep is used.
+func (sys *ParticleSystem) simulate(start time.Time, now time.Time, step time.Duration) {
+	if step <= 0 {
+		step = defaultStep
+	}
+
+	for t := start.Add(step); t.Before(now); t = t.Add(step) {
+		sys.update(t)
+	}
+}
+
+// preallocateParticles fills the free list so that MaxParticles particles can be alive without further allocations.
+func (sys *ParticleSystem) preallocateParticles() {
+	num := sys.MaxParticles - len(sys.particles) - len(sys.free)
+	if num <= 0 {
+		return
+	}
+
+	parts := make([]Particle, num)
+	for idx := range parts {
+		p := &parts[idx]
+		p.system = sys
+		sys.free = append(sys.free, p)
+	}
+}
+
+// removeDeadParticles removes dead particles from the system. If respawn is true, dead particles are respawned
+// according to RespawnMode instead.
+func (sys *ParticleSystem) removeDeadParticles(now time.Time, respawn bool) {
+	sys.beginIteration()
+	defer sys.endIteration()
+
+	numFree := len(sys.free)
+	alive := sys.particles[:0]
+
+	for _, part := range sys.particles {
 		if part.alive(now) {
+			alive = append(alive, part)
 			continue
 		}
 
-		sys.particles = append(sys.particles[:idx], sys.particles[idx+1:]...)
-		sys.pool.Put(part)
+		if respawn && sys.RespawnMode != RespawnNone {
+			if sys.DeathFunc != nil {
+				sys.DeathFunc(part)
+			}
+
+			sys.respawnParticle(part, now)
+
+			sys.respawned = append(sys.respawned, part)
 
-		if sys.DeathFunc != nil {
-			sys.DeathFunc(part)
+			continue
 		}
+
+		sys.free = append(sys.free, part)
+	}
+
+	// respawned particles count as newly spawned, so they go last
+	alive = append(alive, sys.respawned...)
+
+	for idx := range sys.respawned {
+		sys.respawned[idx] = nil
+	}
+
+	sys.respawned = sys.respawned[:0]
+
+	for idx := len(alive); idx < len(sys.particles); idx++ {
+		sys.particles[idx] = nil
+	}
+
+	sys.particles = alive
+
+	if sys.DeathFunc == nil {
+		return
+	}
+
+	for _, part := range sys.free[numFree:] {
+		sys.DeathFunc(part)
 	}
 }
 
 func (sys *ParticleSystem) spawnParticles(now time.Time) {
-	if sys.EmissionRateOverTime != nil {
-		d := sys.Duration(now)
+	if !sys.stopped && sys.EmissionRateOverTime != nil && (sys.EmissionGate == nil || sys.EmissionGate()) {
+		d := sys.emissionTime(now)
 		delta := now.Sub(sys.lastUpdateTime)
-		sys.particlesToEmit += sys.EmissionRateOverTime(d, delta) * delta.Seconds()
+
+		emit := true
+
+		// only count the part of this update that happened before emission has ended
+		if over := sys.duration(now) - sys.EmissionDuration; sys.emissionEnds() && over > 0 {
+			d = sys.EmissionDuration
+			delta -= over
+			emit = delta > 0
+		}
+
+		if emit {
+			sys.particlesToEmit += sys.EmissionRateOverTime(d, delta) * delta.Seconds()
+		}
 	}
 
-	for sys.particlesToEmit >= 1 {
-		sys.spawnParticle(now)
-		sys.particlesToEmit--
+	sys.cullOldestParticles(now)
+
+	num := int(sys.particlesToEmit)
+	sys.particlesToEmit -= float64(num)
+
+	for i := 0; i < num; i++ {
+		if !sys.SubFrameEmission {
+			sys.spawnParticle(now)
+			continue
+		}
+
+		birth := sys.lastUpdateTime.Add(time.Duration(float64(now.Sub(sys.lastUpdateTime)) * float64(i+1) / float64(num)))
+
+		spawned := sys.spawnParticle(birth)
+		if birth.Equal(now) {
+			continue
+		}
+
+		for _, p := range sys.particles[len(sys.particles)-spawned:] {
+			p.update(birth)
+		}
 	}
+
+	sys.spawnBursts(now)
 }
 
-func (sys *ParticleSystem) spawnParticle(now time.Time) {
-	if len(sys.particles) >= sys.MaxParticles {
+func (sys *ParticleSystem) cullOldestParticles(now time.Time) {
+	if sys.CullOldestAfter <= 0 {
+		return
+	}
+
+	if len(sys.particles) < sys.MaxParticles {
+		sys.saturatedSince = time.Time{}
+		return
+	}
+
+	if sys.saturatedSince.IsZero() {
+		sys.saturatedSince = now
+		return
+	}
+
+	if now.Sub(sys.saturatedSince) < sys.CullOldestAfter {
 		return
 	}
 
-	part := sys.pool.Get().(*Particle) //nolint:forcetypeassert // we know this is a *Particle
+	num := int(sys.particlesToEmit)
+	for _, b := range sys.bursts {
+		num += b.Count
+	}
+
+	num *= sys.EmissionSymmetry.count()
+
+	if sys.TotalEmissionLimit > 0 && num > sys.TotalEmissionLimit-sys.numEmitted {
+		num = sys.TotalEmissionLimit - sys.numEmitted
+	}
+
+	if num <= 0 {
+		return
+	}
+
+	for _, p := range sys.particles {
+		if num == 0 {
+			break
+		}
+
+		if !p.isAlive {
+			continue
+		}
+
+		p.Kill()
+		num--
+	}
+
+	sys.removeDeadParticles(now, false)
+}
+
+// spawnParticle spawns a particle, including its symmetry copies, and returns the number of particles spawned.
+func (sys *ParticleSystem) spawnParticle(now time.Time) int {
+	if !sys.canSpawn() {
+		return 0
+	}
+
+	part := sys.newParticle(now)
+	sys.initParticle(part, now, true)
+
+	sys.particles = append(sys.particles, part)
+	sys.numEmitted++
+
+	sym := sys.EmissionSymmetry
+
+	for i := 1; i < sym.count(); i++ {
+		if !sys.canSpawn() {
+			return i
+		}
+
+		c := sys.newParticle(now)
+		c.seed = part.seed
+		c.lifetime = part.lifetime
+		c.deathTime = part.deathTime
+		c.position = sym.transform(i, part.position)
+		c.velocity = sym.transform(i, part.velocity)
+		c.angle = sym.angle(i)
+		c.angularVelocity = sym.spin(i, part.angularVelocity)
+
+		sys.particles = append(sys.particles, c)
+		sys.numEmitted++
+	}
+
+	return sym.count()
+}
+
+// canSpawn returns whether another particle can be spawned without exceeding MaxParticles or TotalEmissionLimit.
+func (sys *ParticleSystem) canSpawn() bool {
+	return len(sys.particles) < sys.MaxParticles && !sys.emissionLimitReached()
+}
+
+func (sys *ParticleSystem) emissionLimitReached() bool {
+	return sys.TotalEmissionLimit > 0 && sys.numEmitted >= sys.TotalEmissionLimit
+}
+
+func (sys *ParticleSystem) respawnParticle(part *Particle, now time.Time) {
+	pos := part.position
 
 	part.reset()
 
-	dur := sys.Duration(now)
+	part.birthTime = now
+	part.lastUpdateTime = now
+
+	if sys.RespawnMode == RespawnInPlace {
+		part.position = pos
+	}
+
+	sys.initParticle(part, now, sys.RespawnMode != RespawnInPlace)
+}
+
+// initParticle initializes a new particle's lifetime, position, and velocity.
+func (sys *ParticleSystem) initParticle(part *Particle, now time.Time, emitPosition bool) {
+	dur := sys.emissionTime(now)
 	delta := now.Sub(sys.lastUpdateTime)
 
 	if sys.LifetimeOverTime != nil {
@@ -204,29 +885,113 @@ func (sys *ParticleSystem) spawnParticle(now time.Time) {
 		part.lifetime = 1 * time.Second
 	}
 
-	part.birthTime = now
 	part.deathTime = now.Add(part.lifetime)
-	part.lastUpdateTime = now
 
-	if sys.EmissionPositionOverTime != nil {
-		part.position = sys.EmissionPositionOverTime(dur, delta)
+	if emitPosition {
+		if sys.AmbientVolumeFunc != nil {
+			part.position = sys.ambientVolume.RandomPoint(sys.Rand(), false)
+		} else if sys.EmissionPositionOverTime != nil {
+			part.position = sys.EmissionPositionOverTime(dur, delta)
+		}
+	}
+
+	if emitPosition && !sys.constrainEmissionPosition(part, dur, delta) {
+		part.Kill()
 	}
 
-	sys.particles = append(sys.particles, part)
+	if sys.InitialVelocityOverTime != nil {
+		part.velocity = sys.InitialVelocityOverTime(dur, delta, part.position)
+	}
+
+	if sys.InitialAngularVelocityOverTime != nil {
+		part.angularVelocity = sys.InitialAngularVelocityOverTime(dur, delta)
+	}
+}
+
+func (sys *ParticleSystem) newParticle(now time.Time) *Particle {
+	var part *Particle
+
+	if len(sys.free) > 0 {
+		part = sys.free[len(sys.free)-1]
+		sys.free[len(sys.free)-1] = nil
+		sys.free = sys.free[:len(sys.free)-1]
+	} else {
+		part = newParticle(sys)
+	}
+
+	part.reset()
+
+	part.birthTime = now
+	part.lastUpdateTime = now
+
+	return part
 }
 
-func (sys *ParticleSystem) updateParticles(now time.Time) bool {
+// updateParticles updates all alive particles. It returns whether particles have died that require another pass
+// in Update, and whether particles have died at all.
+func (sys *ParticleSystem) updateParticles(now time.Time) (bool, bool) {
+	sys.beginIteration()
+	defer sys.endIteration()
+
 	needsMorePasses := false
+	dead := false
+
+	for _, p := range sys.particles {
+		if p.isAlive {
+			p.updateWithTimers(now)
+
+			if p.alive(now) {
+				continue
+			}
+		}
+
+		dead = true
+		needsMorePasses = needsMorePasses || !sys.spawnedDuringUpdate(p, now)
+	}
+
+	return needsMorePasses, dead
+}
+
+// spawnedDuringUpdate returns whether p has been spawned during the current Update. Particles that die right
+// after being spawned (for example, with a lifetime of zero) must not cause another pass, otherwise Update
+// would spawn and kill particles forever.
+func (sys *ParticleSystem) spawnedDuringUpdate(p *Particle, now time.Time) bool {
+	return p.birthTime.Equal(now) || p.birthTime.After(sys.lastUpdateTime)
+}
+
+// KillWhere kills all particles for which pred returns true. The particles will be killed at the start of the next
+// Update, so it is safe to call KillWhere at any time, for example from rendering code.
+func (sys *ParticleSystem) KillWhere(pred ParticlePredicateFunc) {
+	sys.killPredicates = append(sys.killPredicates, pred)
+}
+
+// KillAllInShape kills all particles whose positions are inside of shape at the start of the next Update.
+// The shape is relative to the system's origin.
+func (sys *ParticleSystem) KillAllInShape(shape Shape) {
+	sys.KillWhere(func(p *Particle) bool {
+		return shape.Contains(p.position)
+	})
+}
+
+func (sys *ParticleSystem) applyKillPredicates() {
+	if len(sys.killPredicates) == 0 {
+		return
+	}
 
 	for _, p := range sys.particles {
-		p.update(now)
+		if !p.isAlive {
+			continue
+		}
 
-		if !p.alive(now) {
-			needsMorePasses = true
+		for _, pred := range sys.killPredicates {
+			if pred(p) {
+				p.Kill()
+				break
+			}
 		}
 	}
 
-	return needsMorePasses
+	sys.killPredicates = sys.killPredicates[:0]
 }
 
 // Spawn increases the number of particles to emit on the next Update by num. This can be used
@@ -235,23 +1000,107 @@ func (sys *ParticleSystem) Spawn(num int) {
 	sys.particlesToEmit += float64(num)
 }
 
-// ForEachParticle calls fun for each alive particle in the system. now should usually be time.Now().
+// ForEachParticle calls fun for each alive particle in the system, in the order they have been spawned.
+// now should usually be time.Now().
+//
+// fun may freely mutate the system: Particles killed during the iteration will not be visited afterwards.
+// Particles spawned during the iteration will not be visited, they will be spawned on the next Update.
+// Calls to Update or Reset during the iteration are deferred until the iteration has finished.
 func (sys *ParticleSystem) ForEachParticle(fun ParticleVisitFunc, now time.Time) {
+	sys.beginIteration()
+	defer sys.endIteration()
+
+	now = sys.clock.at(now)
+
 	delta := now.Sub(sys.lastUpdateTime)
 
 	for _, p := range sys.particles {
-		d := p.duration(now)
-		t := NormalizedDuration(d.Seconds() / p.lifetime.Seconds())
-		fun(p, t, delta)
+		if !p.isAlive {
+			continue
+		}
+
+		fun(p, p.normalizedDuration(now), delta)
 	}
 }
 
-// Duration returns the duration of the system at now, that is, how long the system has been active.
-// now should usually be time.Now().
+// NearestTarget returns the index of the target in Targets that is nearest to v. If there are no targets, it returns -1.
+// If WrapQueries is true, distances wrap around WrapBounds.
+func (sys *ParticleSystem) NearestTarget(v Vector) int {
+	nearest := -1
+	nearestDist := 0.0
+
+	for idx, t := range sys.Targets {
+		dist := sys.offset(v, t).Magnitude()
+		if nearest < 0 || dist < nearestDist {
+			nearest = idx
+			nearestDist = dist
+		}
+	}
+
+	return nearest
+}
+
+// Duration returns the duration of the system at now, that is, how long the system has been active, not counting
+// the time it has been paused for (see Pause.) now should usually be time.Now().
 func (sys *ParticleSystem) Duration(now time.Time) time.Duration {
+	return sys.duration(sys.clock.at(now))
+}
+
+// duration returns the duration of the system at simulation time now.
+func (sys *ParticleSystem) duration(now time.Time) time.Duration {
 	return now.Sub(sys.startTime)
 }
 
+// wrapBounds returns the rectangle that the positions of particles are wrapped around.
+func (sys *ParticleSystem) wrapBounds() Rect {
+	if sys.AmbientVolumeFunc != nil {
+		return sys.ambientVolume
+	}
+
+	return sys.WrapBounds
+}
+
+// emissionTime returns the duration of the system at now as used for spawning particles, which starts over after
+// each EmissionDuration if Looping is set.
+func (sys *ParticleSystem) emissionTime(now time.Time) time.Duration {
+	d := sys.duration(now)
+	if sys.Looping && sys.EmissionDuration > 0 {
+		d %= sys.EmissionDuration
+	}
+
+	return d
+}
+
+// emissionEnds returns whether the system stops spawning particles after EmissionDuration.
+func (sys *ParticleSystem) emissionEnds() bool {
+	return sys.EmissionDuration > 0 && !sys.Looping
+}
+
+// emissionEnded returns whether the system has stopped spawning particles at now, either because EmissionDuration
+// has passed without Looping, or because the system has been stopped.
+func (sys *ParticleSystem) emissionEnded(now time.Time) bool {
+	return sys.stopped || (sys.emissionEnds() && sys.duration(now) >= sys.EmissionDuration)
+}
+
+// checkComplete calls CompleteFunc if the system has completed at now.
+func (sys *ParticleSystem) checkComplete(now time.Time) {
+	if sys.completed || !sys.emissionEnded(now) || len(sys.particles) > 0 || len(sys.bursts) > 0 || sys.particlesToEmit >= 1.0 {
+		return
+	}
+
+	sys.completed = true
+
+	if sys.CompleteFunc != nil {
+		sys.CompleteFunc()
+	}
+}
+
+// NumEmitted returns the total number of particles that have been spawned since the system has been started or reset,
+// as counted by TotalEmissionLimit.
+func (sys *ParticleSystem) NumEmitted() int {
+	return sys.numEmitted
+}
+
 // NumParticles returns the number of alive particles.
 func (sys *ParticleSystem) NumParticles() int {
 	return len(sys.particles)
@@ -259,20 +1108,125 @@ func (sys *ParticleSystem) NumParticles() int {
 
 // Reset kills all alive particles and completely resets the system.
 // DeathFunc will be called for all particles that were alive.
+//
+// If Reset is called while the system's particles are being iterated over (for example, from a function passed
+// to ForEachParticle), the reset is deferred until the iteration has finished.
 func (sys *ParticleSystem) Reset() {
+	if sys.deferIfIterating(sys.Reset) {
+		return
+	}
+
 	for _, p := range sys.particles {
 		p.Kill()
 	}
 
-	sys.removeDeadParticles(time.Now())
+	sys.removeDeadParticles(time.Now(), false)
 
 	sys.initOnce = sync.Once{}
 	sys.particles = nil
 	sys.particlesToEmit = 0.0
+	sys.numEmitted = 0
+	sys.lastID = 0
+	sys.budgetLastID = 0
+	sys.bursts = nil
+	sys.killPredicates = nil
+	sys.saturatedSince = time.Time{}
+	sys.startTime = time.Time{}
+	sys.lastUpdateTime = time.Time{}
+	sys.completed = false
+	sys.wasCulled = false
+	sys.stopped = false
+	sys.clock = virtualClock{scale: sys.clock.scale, scaleSet: sys.clock.scaleSet}
+
+	if sys.rand != nil && !sys.randSet {
+		sys.rand.Seed(sys.initialSeed())
+	}
+
+	sys.resetPeriodicFuncs()
+}
+
+// Stop stops the system according to mode. Unlike Reset, Stop keeps the system's duration and all other state.
+// Particles spawned using Spawn or SpawnBurst after Stop has been called are still spawned. Reset un-stops
+// the system.
+//
+// If Stop is called while the system's particles are being iterated over (for example, from a function passed
+// to ForEachParticle), stopping is deferred until the iteration has finished.
+func (sys *ParticleSystem) Stop(mode StopMode) {
+	if sys.deferIfIterating(func() { sys.Stop(mode) }) {
+		return
+	}
+
+	sys.stopped = true
+	sys.particlesToEmit = 0.0
+
+	if mode != StopAndClear {
+		return
+	}
+
+	for _, p := range sys.particles {
+		p.Kill()
+	}
+
+	sys.removeDeadParticles(time.Now(), false)
+
+	sys.bursts = sys.bursts[:0]
+}
+
+// Stopped returns whether the system has been stopped (see Stop.)
+func (sys *ParticleSystem) Stopped() bool {
+	return sys.stopped
+}
+
+func (sys *ParticleSystem) beginIteration() {
+	sys.iterating++
+}
+
+func (sys *ParticleSystem) endIteration() {
+	sys.iterating--
+	if sys.iterating > 0 {
+		return
+	}
+
+	for len(sys.deferred) > 0 {
+		fun := sys.deferred[0]
+		sys.deferred = sys.deferred[1:]
+		fun()
+	}
 }
 
-// Duration converts t to a duration with respect to the longer duration m.
+// deferIfIterating defers fun until the current iteration over the system's particles has finished.
+// It returns false if the particles are not currently being iterated over.
+func (sys *ParticleSystem) deferIfIterating(fun func()) bool {
+	if sys.iterating == 0 {
+		return false
+	}
+
+	sys.deferred = append(sys.deferred, fun)
+
+	return true
+}
+
+// Duration converts t to a duration with respect to the longer duration m. t is clamped to the range [0.0,1.0] first.
 // If t is 0, it will return 0, and if t is 1, it will return m.
 func (t NormalizedDuration) Duration(m time.Duration) time.Duration {
-	return time.Duration(float64(m.Nanoseconds()) * float64(t))
+	d := float64(m.Nanoseconds()) * float64(t.clamp())
+
+	// float64(m) may be rounded away from zero, and converting it back would overflow
+	if math.Abs(d) >= math.Abs(float64(m.Nanoseconds())) {
+		return m
+	}
+
+	return time.Duration(d)
+}
+
+// clamp returns t clamped to the range [0.0,1.0]. If t is NaN, it returns 0.0.
+func (t NormalizedDuration) clamp() NormalizedDuration {
+	switch {
+	case t > 1.0:
+		return 1.0
+	case t >= 0.0:
+		return t
+	default:
+		return 0.0
+	}
 }
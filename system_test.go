@@ -1,6 +1,8 @@
 package twodeeparticles
 
 import (
+	"math"
+	"math/rand"
 	"testing"
 	"time"
 
@@ -64,6 +66,36 @@ func TestParticleSystem_Update_SpawnMoreAfterKill(t *testing.T) {
 	is.True(killCalled)
 }
 
+func TestParticleSystem_Update_InitialVelocity(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+
+	sys.MaxParticles = 1
+
+	sys.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+		return Vector{3, 5}
+	}
+
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	var part *Particle
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		part = p
+	}, now)
+
+	is.Equal(part.Velocity(), Vector{3, 5})
+
+	now = now.Add(500 * time.Millisecond)
+	sys.Update(now)
+
+	is.Equal(part.Position(), Vector{1.5, 2.5})
+}
+
 func TestParticleSystem_Spawn(t *testing.T) {
 	is := is.New(t)
 
@@ -83,3 +115,1103 @@ func TestNormalizedDuration_Duration(t *testing.T) {
 	is := is.New(t)
 	is.Equal(NormalizedDuration(0.2).Duration(5000*time.Millisecond), 1000*time.Millisecond)
 }
+
+func TestParticleSystem_KillWhere(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+
+	sys.MaxParticles = 10
+
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 10 * time.Second
+	}
+
+	sys.Spawn(10)
+
+	now := time.Now()
+	sys.Update(now)
+
+	idx := 0
+	sys.KillWhere(func(p *Particle) bool {
+		idx++
+		return idx%2 == 0
+	})
+
+	is.Equal(sys.NumParticles(), 10)
+
+	now = now.Add(10 * time.Millisecond)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 5)
+}
+
+func TestParticleSystem_KillAllInShape(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+
+	sys.MaxParticles = 10
+
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 10 * time.Second
+	}
+
+	x := 0.0
+	sys.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) Vector {
+		x++
+		return Vector{x, 0}
+	}
+
+	sys.Spawn(10)
+
+	now := time.Now()
+	sys.Update(now)
+
+	sys.KillAllInShape(Rect{Vector{0, -1}, Vector{4, 1}})
+
+	now = now.Add(10 * time.Millisecond)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 7)
+}
+
+func TestParticleSystem_ForEachParticle_Kill(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+
+	sys.MaxParticles = 10
+
+	sys.Spawn(10)
+
+	now := time.Now()
+	sys.Update(now)
+
+	var parts []*Particle
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		parts = append(parts, p)
+	}, now)
+
+	visited := 0
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		visited++
+
+		parts[len(parts)-visited].Kill()
+	}, now)
+
+	is.Equal(visited, 5)
+}
+
+func TestParticleSystem_ForEachParticle_SpawnAndReset(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+
+	sys.MaxParticles = 10
+
+	sys.Spawn(5)
+
+	now := time.Now()
+	sys.Update(now)
+
+	deaths := 0
+	sys.DeathFunc = func(p *Particle) {
+		deaths++
+	}
+
+	visited := 0
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		visited++
+
+		sys.Spawn(1)
+		sys.Reset()
+
+		is.Equal(sys.NumParticles(), 5)
+	}, now)
+
+	is.Equal(visited, 5)
+	is.Equal(deaths, 5)
+	is.Equal(sys.NumParticles(), 0)
+}
+
+func TestParticleSystem_ForEachParticle_Update(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+
+	sys.MaxParticles = 10
+
+	sys.Spawn(5)
+
+	now := time.Now()
+	sys.Update(now)
+
+	visited := 0
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		visited++
+
+		if visited == 1 {
+			sys.Spawn(5)
+			sys.Update(now.Add(10 * time.Millisecond))
+		}
+	}, now)
+
+	is.Equal(visited, 5)
+	is.Equal(sys.NumParticles(), 10)
+}
+
+func TestParticleSystem_Update_SubFrameEmission(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+
+	sys.MaxParticles = 10
+
+	sys.SubFrameEmission = true
+
+	sys.EmissionRateOverTime = func(d time.Duration, delta time.Duration) float64 {
+		return 4.0
+	}
+
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 10 * time.Second
+	}
+
+	sys.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+		return Vector{0, 8}
+	}
+
+	firstDeltas := map[*Particle]time.Duration{}
+	sys.UpdateFunc = func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		if t == 0 {
+			firstDeltas[p] = delta
+		}
+	}
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 4)
+
+	var positions []Vector
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		positions = append(positions, p.Position())
+		is.Equal(firstDeltas[p], time.Duration(0))
+	}, now)
+
+	is.Equal(positions, []Vector{{0, 6}, {0, 4}, {0, 2}, {0, 0}})
+}
+
+func TestParticleSystem_Update_CullOldest(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+
+	sys.MaxParticles = 3
+
+	sys.CullOldestAfter = 2 * time.Second
+
+	sys.EmissionRateOverTime = func(d time.Duration, delta time.Duration) float64 {
+		return 1.0
+	}
+
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return InfiniteLifetime
+	}
+
+	var births []time.Duration
+	sys.UpdateFunc = func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		if p.Age() == 0 {
+			births = append(births, p.System().Duration(p.birthTime))
+		}
+	}
+
+	start := time.Now()
+	now := start
+	sys.Update(now)
+
+	for i := 0; i < 4; i++ {
+		now = now.Add(1 * time.Second)
+		sys.Update(now)
+	}
+
+	is.Equal(sys.NumParticles(), 3)
+	is.Equal(len(births), 3)
+
+	for i := 0; i < 3; i++ {
+		now = now.Add(1 * time.Second)
+		sys.Update(now)
+	}
+
+	is.Equal(sys.NumParticles(), 3)
+	is.Equal(len(births), 5)
+
+	var oldest time.Duration
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		if oldest == 0 {
+			oldest = p.birthTime.Sub(start)
+		}
+	}, now)
+
+	is.Equal(oldest, 3*time.Second)
+}
+
+func TestParticleSystem_Update_RespawnInPlace(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+
+	sys.MaxParticles = 1
+
+	sys.RespawnMode = RespawnInPlace
+
+	sys.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) Vector {
+		return Vector{17, 23}
+	}
+
+	sys.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+		return Vector{3, 5}
+	}
+
+	deaths := 0
+	sys.DeathFunc = func(p *Particle) {
+		deaths++
+	}
+
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	var part *Particle
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		part = p
+	}, now)
+
+	now = now.Add(500 * time.Millisecond)
+	sys.Update(now)
+
+	now = now.Add(500 * time.Millisecond)
+	sys.Update(now)
+
+	is.Equal(deaths, 1)
+	is.Equal(sys.NumParticles(), 1)
+	is.Equal(part.Age(), time.Duration(0))
+	is.Equal(part.Position(), Vector{18.5, 25.5})
+	is.Equal(part.SpawnPosition(), Vector{18.5, 25.5})
+
+	sys.Reset()
+
+	is.Equal(deaths, 2)
+	is.Equal(sys.NumParticles(), 0)
+}
+
+func TestParticleSystem_Update_RespawnAtEmissionPosition(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+
+	sys.MaxParticles = 1
+
+	sys.RespawnMode = RespawnAtEmissionPosition
+
+	sys.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) Vector {
+		return Vector{17, 23}
+	}
+
+	sys.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+		return Vector{3, 5}
+	}
+
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 1)
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.Equal(p.Position(), Vector{17, 23})
+	}, now)
+}
+
+func TestParticleSystem_Update_WrapBounds(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+
+	sys.MaxParticles = 1
+
+	sys.WrapBounds = Rect{Vector{-10, -10}, Vector{10, 10}}
+
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return InfiniteLifetime
+	}
+
+	sys.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+		return Vector{15, -5}
+	}
+
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.Equal(p.Position(), Vector{-5, -5})
+		is.Equal(p.DistanceTravelled(), Vector{15, -5}.Magnitude())
+	}, now)
+}
+
+func BenchmarkParticleSystem_Update(b *testing.B) {
+	sys := NewSystem()
+
+	sys.MaxParticles = 10000
+
+	sys.EmissionRateOverTime = func(d time.Duration, delta time.Duration) float64 {
+		return 100000.0
+	}
+
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 100 * time.Millisecond
+	}
+
+	sys.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+		return Vector{3, 5}
+	}
+
+	now := time.Now()
+	sys.Update(now)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		now = now.Add(16 * time.Millisecond)
+		sys.Update(now)
+	}
+}
+
+func TestParticleSystem_ForEachParticle_SpawnOrder(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+
+	sys.MaxParticles = 50
+
+	sys.RespawnMode = RespawnAtEmissionPosition
+
+	sys.EmissionRateOverTime = func(d time.Duration, delta time.Duration) float64 {
+		return 100.0
+	}
+
+	lifetime := 0
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		lifetime = (lifetime + 7) % 13
+		return time.Duration(lifetime+1) * 50 * time.Millisecond
+	}
+
+	births := map[*Particle]int{}
+	numBirths := 0
+	sys.UpdateFunc = func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		if p.Age() == 0 {
+			numBirths++
+			births[p] = numBirths
+		}
+	}
+
+	now := time.Now()
+	sys.Update(now)
+
+	for i := 0; i < 100; i++ {
+		now = now.Add(20 * time.Millisecond)
+		sys.Update(now)
+
+		prev := 0
+
+		sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+			is.True(births[p] > prev)
+			prev = births[p]
+		}, now)
+	}
+}
+
+func TestParticleSystem_NearestTarget(t *testing.T) {
+	is := is.New(t)
+
+	s := NewSystem()
+	is.Equal(s.NearestTarget(ZeroVector), -1)
+
+	s.Targets = []Vector{{10, 0}, {-3, 0}, {0, 5}}
+	is.Equal(s.NearestTarget(ZeroVector), 1)
+	is.Equal(s.NearestTarget(Vector{1, 4}), 2)
+
+	s.WrapBounds = Rect{Vector{-10, -10}, Vector{10, 10}}
+	s.WrapQueries = true
+	is.Equal(s.NearestTarget(Vector{-9, 0}), 0)
+}
+
+func TestParticleSystem_InitialVelocityOverTime_SpawnPosition(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 1
+	sys.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) Vector {
+		return Vector{10, 20}
+	}
+
+	calls := 0
+	sys.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+		calls++
+		return spawnPos.Multiply(0.5)
+	}
+
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	for i := 0; i < 3; i++ {
+		now = now.Add(100 * time.Millisecond)
+		sys.Update(now)
+	}
+
+	is.Equal(calls, 1)
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.Equal(p.Velocity(), Vector{5, 10})
+		is.Equal(p.StartVelocity(), Vector{5, 10})
+	}, now)
+}
+
+func FuzzNormalizedDuration_Duration(f *testing.F) {
+	f.Add(0.5, int64(time.Second))
+	f.Add(-1.0, int64(time.Second))
+	f.Add(2.0, int64(time.Minute))
+	f.Add(math.NaN(), int64(time.Second))
+	f.Add(math.Inf(1), int64(0))
+	f.Add(1.0, int64(math.MaxInt64))
+	f.Add(0.9999999999, int64(math.MaxInt64))
+	f.Add(1.0, int64(math.MinInt64))
+
+	f.Fuzz(func(t *testing.T, n float64, m int64) {
+		is := is.New(t)
+
+		switch {
+		case m == math.MinInt64:
+			m = math.MaxInt64
+		case m < 0:
+			m = -m
+		}
+
+		d := NormalizedDuration(n).Duration(time.Duration(m))
+		is.True(d >= 0 && d <= time.Duration(m))
+	})
+}
+
+func TestParticleSystem_Update_ZeroLifetime(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 10
+	sys.EmissionRateOverTime = func(d time.Duration, delta time.Duration) float64 {
+		return 100.0
+	}
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 0
+	}
+
+	sys.UpdateFunc = func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.Equal(t, NormalizedDuration(1.0))
+	}
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(100 * time.Millisecond)
+	sys.Update(now)
+
+	sys.SubFrameEmission = true
+
+	now = now.Add(100 * time.Millisecond)
+	sys.Update(now)
+}
+
+func TestParticleSystem_Update_KillAtBirth(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 10
+	sys.EmissionRateOverTime = func(d time.Duration, delta time.Duration) float64 {
+		return 100.0
+	}
+
+	spawned := 0
+	sys.UpdateFunc = func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		spawned++
+		p.Kill()
+	}
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(100 * time.Millisecond)
+	sys.Update(now)
+
+	is.Equal(spawned, 10)
+	is.Equal(sys.NumParticles(), 0)
+}
+
+func TestParticleSystem_Update_Invariants(t *testing.T) {
+	rand := rand.New(rand.NewSource(1)) //nolint:gosec // no crypto
+
+	lifetimes := []time.Duration{0, 50 * time.Millisecond, 300 * time.Millisecond, 2 * time.Second, InfiniteLifetime}
+
+	for cfg := 0; cfg < 50; cfg++ {
+		is := is.New(t)
+
+		sys := NewSystem()
+		sys.MaxParticles = rand.Intn(50)
+		sys.RespawnMode = RespawnMode(rand.Intn(3))
+		sys.SubFrameEmission = rand.Intn(2) == 0
+		sys.CullOldestAfter = time.Duration(rand.Intn(3)) * 100 * time.Millisecond
+
+		rate := rand.Float64() * 500.0
+		sys.EmissionRateOverTime = func(d time.Duration, delta time.Duration) float64 {
+			return rate
+		}
+
+		lifetime := lifetimes[rand.Intn(len(lifetimes))]
+		sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+			return lifetime
+		}
+
+		spawned := 0
+		sys.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+			spawned++
+			return Vector{1, 1}
+		}
+
+		deaths := 0
+		sys.DeathFunc = func(p *Particle) {
+			deaths++
+		}
+
+		checkT := func(p *Particle, t NormalizedDuration, delta time.Duration) {
+			is.True(t >= 0.0 && t <= 1.0)
+		}
+
+		sys.UpdateFunc = checkT
+
+		now := time.Now()
+
+		for i := 0; i < 100; i++ {
+			now = now.Add(time.Duration(rand.Intn(100)) * time.Millisecond)
+
+			switch rand.Intn(10) {
+			case 0:
+				sys.Spawn(rand.Intn(20))
+			case 1:
+				sys.KillWhere(func(p *Particle) bool {
+					return rand.Intn(2) == 0
+				})
+			}
+
+			sys.Update(now)
+
+			is.True(sys.NumParticles() <= sys.MaxParticles)
+			sys.ForEachParticle(checkT, now)
+		}
+
+		sys.Reset()
+
+		is.Equal(sys.NumParticles(), 0)
+		sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+			is.Fail()
+		}, now)
+
+		is.Equal(deaths, spawned)
+	}
+}
+
+func TestParticleSystem_Update_EmissionGate(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 100
+	sys.EmissionRateOverTime = func(d time.Duration, delta time.Duration) float64 {
+		return 10.0
+	}
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return InfiniteLifetime
+	}
+
+	open := false
+	sys.EmissionGate = func() bool {
+		return open
+	}
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 0)
+
+	sys.Spawn(2)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 2)
+
+	open = true
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 12)
+}
+
+func TestParticleSystem_Update_TotalEmissionLimit(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 100
+	sys.TotalEmissionLimit = 15
+	sys.EmissionRateOverTime = func(d time.Duration, delta time.Duration) float64 {
+		return 10.0
+	}
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 1500 * time.Millisecond
+	}
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 10)
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 15)
+	is.Equal(sys.NumEmitted(), 15)
+
+	sys.Spawn(5)
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 5)
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 0)
+	is.Equal(sys.NumEmitted(), 15)
+
+	sys.Reset()
+	is.Equal(sys.NumEmitted(), 0)
+}
+
+func TestParticleSystem_Update_Deterministic(t *testing.T) {
+	is := is.New(t)
+
+	run := func() ([]uint64, []Vector, []float64, []time.Duration) {
+		rand := rand.New(rand.NewSource(1)) //nolint:gosec // no crypto
+
+		sys := NewSystem()
+		sys.Deterministic = true
+		sys.MaxParticles = 50
+		sys.RespawnMode = RespawnAtEmissionPosition
+		sys.Separation = Separation{Radius: 5.0, Strength: 10.0}
+		sys.EmissionRateOverTime = func(d time.Duration, delta time.Duration) float64 {
+			return 20.0
+		}
+		sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+			return time.Second
+		}
+		sys.EmissionPositionOverTime = RandomWalkPositionOverTime(ZeroVector, 3.0, 20.0, rand)
+		sys.InitialVelocityOverTime = AngleSweep{AngularSpeed: 1.0, Spread: 1.0}.VelocityOverTime(10.0, rand)
+
+		sys.SpawnBurst(Burst{Count: 10, LifetimeStagger: time.Second, StaggerMode: StaggerRandom})
+
+		now := time.Unix(0, 0)
+		for i := 0; i < 100; i++ {
+			now = now.Add(30 * time.Millisecond)
+			sys.Update(now)
+		}
+
+		var (
+			ids       []uint64
+			positions []Vector
+			randoms   []float64
+			lifetimes []time.Duration
+		)
+
+		sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+			ids = append(ids, p.ID())
+			positions = append(positions, p.Position())
+			randoms = append(randoms, p.Random(0))
+			lifetimes = append(lifetimes, p.Lifetime())
+		}, now)
+
+		return ids, positions, randoms, lifetimes
+	}
+
+	ids1, positions1, randoms1, lifetimes1 := run()
+	ids2, positions2, randoms2, lifetimes2 := run()
+
+	is.True(len(ids1) > 0)
+	is.Equal(ids1, ids2)
+	is.Equal(positions1, positions2)
+	is.Equal(randoms1, randoms2)
+	is.Equal(lifetimes1, lifetimes2)
+
+	for idx := 1; idx < len(ids1); idx++ {
+		is.True(ids1[idx] > ids1[idx-1])
+	}
+}
+
+func TestParticleSystem_Update_Prewarm(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 100
+	sys.Prewarm = 5 * time.Second
+	sys.EmissionRateOverTime = func(d time.Duration, delta time.Duration) float64 {
+		return 10.0
+	}
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 1 * time.Second
+	}
+
+	now := time.Now()
+	sys.Update(now)
+
+	is.True(sys.NumParticles() >= 9 && sys.NumParticles() <= 11)
+	is.True(sys.NumEmitted() >= 49 && sys.NumEmitted() <= 51)
+	is.Equal(sys.Duration(now), 5*time.Second)
+
+	oldest := NormalizedDuration(0.0)
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		if t > oldest {
+			oldest = t
+		}
+	}, now)
+
+	is.True(oldest > 0.8)
+}
+
+func TestParticleSystem_Update_EmissionDuration(t *testing.T) {
+	is := is.New(t)
+
+	completed := 0
+
+	sys := NewSystem()
+	sys.MaxParticles = 100
+	sys.EmissionDuration = 2 * time.Second
+	sys.EmissionRateOverTime = func(d time.Duration, delta time.Duration) float64 {
+		return 10.0
+	}
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 1 * time.Second
+	}
+	sys.CompleteFunc = func() {
+		completed++
+	}
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(1500 * time.Millisecond)
+	sys.Update(now)
+
+	is.Equal(sys.NumEmitted(), 15)
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	is.Equal(sys.NumEmitted(), 20)
+	is.Equal(completed, 0)
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	is.Equal(sys.NumEmitted(), 20)
+	is.Equal(sys.NumParticles(), 0)
+	is.Equal(completed, 1)
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	is.Equal(completed, 1)
+
+	sys.Reset()
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	is.Equal(sys.NumEmitted(), 0)
+
+	now = now.Add(3 * time.Second)
+	sys.Update(now)
+
+	is.Equal(sys.NumEmitted(), 20)
+	is.Equal(completed, 1)
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	is.Equal(completed, 2)
+}
+
+func TestParticleSystem_Update_Looping(t *testing.T) {
+	is := is.New(t)
+
+	completed := false
+	durations := []time.Duration{}
+
+	sys := NewSystem()
+	sys.MaxParticles = 100
+	sys.EmissionDuration = 2 * time.Second
+	sys.Looping = true
+	sys.EmissionRateOverTime = func(d time.Duration, delta time.Duration) float64 {
+		durations = append(durations, d)
+		return 1.0
+	}
+	sys.CompleteFunc = func() {
+		completed = true
+	}
+
+	now := time.Now()
+	sys.Update(now)
+
+	for i := 0; i < 5; i++ {
+		now = now.Add(1 * time.Second)
+		sys.Update(now)
+	}
+
+	is.Equal(durations, []time.Duration{0, 1 * time.Second, 0, 1 * time.Second, 0, 1 * time.Second})
+	is.Equal(sys.NumEmitted(), 5)
+	is.True(!completed)
+}
+
+func TestParticleSystem_Update_Culled(t *testing.T) {
+	is := is.New(t)
+
+	deltas := []time.Duration{}
+
+	sys := NewSystem()
+	sys.MaxParticles = 100
+	sys.CatchUpStep = 250 * time.Millisecond
+	sys.EmissionRateOverTime = func(d time.Duration, delta time.Duration) float64 {
+		deltas = append(deltas, delta)
+		return 10.0
+	}
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 10 * time.Second
+	}
+
+	now := time.Now()
+	sys.Update(now)
+
+	sys.Culled = true
+	deltas = deltas[:0]
+
+	for i := 0; i < 4; i++ {
+		now = now.Add(500 * time.Millisecond)
+		sys.Update(now)
+	}
+
+	is.Equal(sys.NumParticles(), 0)
+	is.Equal(len(deltas), 0)
+
+	sys.Culled = false
+
+	now = now.Add(100 * time.Millisecond)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 21)
+	is.Equal(len(deltas), 9)
+
+	for _, d := range deltas[:8] {
+		is.Equal(d, 250*time.Millisecond)
+	}
+
+	is.Equal(deltas[8], 100*time.Millisecond)
+}
+
+func TestParticleSystem_Update_MaxCatchUp(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 100
+	sys.MaxCatchUp = 1 * time.Second
+	sys.EmissionRateOverTime = func(d time.Duration, delta time.Duration) float64 {
+		return 10.0
+	}
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 10 * time.Second
+	}
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 10)
+
+	sys.Culled = true
+
+	now = now.Add(5 * time.Second)
+	sys.Update(now)
+
+	sys.Culled = false
+
+	now = now.Add(1 * time.Second)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 10)
+	is.Equal(sys.NumEmitted(), 20)
+	is.Equal(sys.Duration(now), 7*time.Second)
+}
+
+func TestParticleSystem_Stop_StopEmitting(t *testing.T) {
+	is := is.New(t)
+
+	completed := false
+
+	sys := NewSystem()
+	sys.MaxParticles = 100
+	sys.RespawnMode = RespawnAtEmissionPosition
+	sys.EmissionRateOverTime = func(d time.Duration, delta time.Duration) float64 {
+		return 10.0
+	}
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 1 * time.Second
+	}
+	sys.CompleteFunc = func() {
+		completed = true
+	}
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(500 * time.Millisecond)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 5)
+
+	sys.Stop(StopEmitting)
+	is.True(sys.Stopped())
+
+	now = now.Add(500 * time.Millisecond)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 5)
+	is.True(!completed)
+
+	now = now.Add(500 * time.Millisecond)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 0)
+	is.Equal(sys.NumEmitted(), 5)
+	is.True(completed)
+	is.Equal(sys.Duration(now), 1500*time.Millisecond)
+}
+
+func TestParticleSystem_Stop_StopAndClear(t *testing.T) {
+	is := is.New(t)
+
+	deaths := 0
+
+	sys := NewSystem()
+	sys.MaxParticles = 100
+	sys.EmissionRateOverTime = func(d time.Duration, delta time.Duration) float64 {
+		return 10.0
+	}
+	sys.DeathFunc = func(p *Particle) {
+		deaths++
+	}
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(500 * time.Millisecond)
+	sys.Update(now)
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		sys.Stop(StopAndClear)
+		is.True(!sys.Stopped())
+	}, now)
+
+	is.True(sys.Stopped())
+	is.Equal(sys.NumParticles(), 0)
+	is.Equal(deaths, 5)
+
+	sys.Spawn(2)
+
+	now = now.Add(500 * time.Millisecond)
+	sys.Update(now)
+
+	is.Equal(sys.NumParticles(), 2)
+
+	sys.Reset()
+	is.True(!sys.Stopped())
+}
+
+func TestParticleSystem_Update_AmbientVolume(t *testing.T) {
+	is := is.New(t)
+
+	camera := Rect{Vector{0, 0}, Vector{100, 100}}
+
+	sys := NewSystem()
+	sys.MaxParticles = 50
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return InfiniteLifetime
+	}
+	sys.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) Vector {
+		return Vector{-1000, -1000}
+	}
+	sys.AmbientVolumeFunc = func() Rect {
+		return camera
+	}
+	sys.Spawn(50)
+
+	now := time.Now()
+	sys.Update(now)
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.True(camera.Contains(p.Position()))
+	}, now)
+
+	camera = Rect{Vector{60, 0}, Vector{160, 100}}
+
+	now = now.Add(100 * time.Millisecond)
+	sys.Update(now)
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.True(camera.Contains(p.Position()))
+	}, now)
+}
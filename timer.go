@@ -0,0 +1,97 @@
+package twodeeparticles
+
+import "time"
+
+// A particleTimer calls fun when a particle reaches a certain age.
+type particleTimer struct {
+	age time.Duration
+	fun func(p *Particle)
+}
+
+// After schedules fun to be called when d has passed, measured from p's current age. Timers are precise: if a timer
+// is due between two updates, p is updated to the exact time the timer is due first, then fun is called, and then p is
+// updated as usual. This makes actions such as "split after 0.5 seconds" independent of the frame rate. To schedule
+// an action at a point of p's lifetime, use NormalizedDuration.Duration (for example,
+// p.After(NormalizedDuration(0.8).Duration(p.Lifetime())-p.Age(), fun).)
+//
+// Timers are usually scheduled from ParticleSystem.UpdateFunc, or from other timers. Timers that are due after p has
+// died are not called. When p is respawned (see RespawnMode), its timers are discarded.
+func (p *Particle) After(d time.Duration, fun func(p *Particle)) {
+	p.timers = append(p.timers, particleTimer{
+		age: p.age + d,
+		fun: fun,
+	})
+}
+
+// updateWithTimers updates p, and calls all timers that are due until now.
+func (p *Particle) updateWithTimers(now time.Time) {
+	if len(p.timers) == 0 {
+		p.update(now)
+		return
+	}
+
+	// timers due before now, with p updated to the exact time
+	for p.isAlive {
+		idx := p.nextTimer(now, false)
+		if idx < 0 {
+			break
+		}
+
+		at := p.birthTime.Add(p.timers[idx].age)
+		if !p.alive(at) {
+			break
+		}
+
+		if at.After(p.lastUpdateTime) {
+			p.update(at)
+		}
+
+		p.fireTimer(idx)
+	}
+
+	if !p.isAlive {
+		return
+	}
+
+	p.update(now)
+
+	// timers due exactly now
+	for p.alive(now) {
+		idx := p.nextTimer(now, true)
+		if idx < 0 {
+			break
+		}
+
+		p.fireTimer(idx)
+	}
+}
+
+// nextTimer returns the index of the earliest timer that is due before now (or at now, if inclusive is set),
+// or -1 if there is none.
+func (p *Particle) nextTimer(now time.Time, inclusive bool) int {
+	age := p.duration(now)
+	next := -1
+
+	for idx, t := range p.timers {
+		if t.age > age || (!inclusive && t.age == age) {
+			continue
+		}
+
+		if next < 0 || t.age < p.timers[next].age {
+			next = idx
+		}
+	}
+
+	return next
+}
+
+// fireTimer removes the timer at index idx and calls it.
+func (p *Particle) fireTimer(idx int) {
+	fun := p.timers[idx].fun
+
+	copy(p.timers[idx:], p.timers[idx+1:])
+	p.timers[len(p.timers)-1] = particleTimer{}
+	p.timers = p.timers[:len(p.timers)-1]
+
+	fun(p)
+}
@@ -0,0 +1,74 @@
+package twodeeparticles
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestParticle_After(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 1
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 2 * time.Second
+	}
+	sys.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+		return Vector{10, 0}
+	}
+
+	var (
+		ages      []time.Duration
+		positions []Vector
+	)
+
+	record := func(p *Particle) {
+		ages = append(ages, p.Age())
+		positions = append(positions, p.Position())
+	}
+
+	sys.UpdateFunc = func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		if p.Age() > 0 {
+			return
+		}
+
+		p.After(500*time.Millisecond, func(p *Particle) {
+			record(p)
+
+			p.After(200*time.Millisecond, record)
+		})
+
+		p.After(time.Second, record)
+		p.After(5*time.Second, record)
+	}
+
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(300 * time.Millisecond)
+	sys.Update(now)
+	is.Equal(len(ages), 0)
+
+	// timers are called at their exact times, regardless of the frame rate
+	now = now.Add(time.Second)
+	sys.Update(now)
+	is.Equal(ages, []time.Duration{500 * time.Millisecond, 700 * time.Millisecond, time.Second})
+	is.True(approxEqualVector(positions[0], Vector{5, 0}))
+	is.True(approxEqualVector(positions[1], Vector{7, 0}))
+	is.True(approxEqualVector(positions[2], Vector{10, 0}))
+
+	// the particle is updated as usual afterwards
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.True(approxEqualVector(p.Position(), Vector{13, 0}))
+	}, now)
+
+	// timers due after the particle has died are not called
+	now = now.Add(5 * time.Second)
+	sys.Update(now)
+	is.Equal(len(ages), 3)
+	is.Equal(sys.NumParticles(), 0)
+}
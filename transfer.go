@@ -0,0 +1,72 @@
+package twodeeparticles
+
+import "time"
+
+// TransferTo moves all alive particles for which pred returns true from sys to other. The particles keep their state,
+// including their ages, lifetimes, positions, velocities, and data, and are updated by other from then on, using
+// other's callbacks. DeathFunc is not called for the particles, and they are not counted as spawned by other (see
+// TotalEmissionLimit.) This can be used to hand particles over to a system with different behavior, for example
+// when smoke particles enter an area with suction.
+//
+// Particle positions are relative to a system's origin, and are not changed. If the systems have different origins,
+// other's callbacks must take the difference into account. Particles receive new IDs in other (see Particle.ID.)
+// If other has reached MaxParticles, the remaining particles stay in sys.
+//
+// The systems' clocks may differ, for example if they have been paused or have different time scales (see Pause and
+// SetTimeScale.) The particles' times are moved from the time of the most recent update of sys to that of other,
+// so that they keep their ages. If either system has not been updated yet, the particles' times are not changed.
+//
+// If TransferTo is called while the particles of sys or other are being iterated over (for example, from a function
+// passed to ForEachParticle), the transfer is deferred until the iteration has finished.
+func (sys *ParticleSystem) TransferTo(other *ParticleSystem, pred ParticlePredicateFunc) {
+	if other == sys {
+		return
+	}
+
+	transfer := func() {
+		sys.TransferTo(other, pred)
+	}
+
+	if sys.deferIfIterating(transfer) || other.deferIfIterating(transfer) {
+		return
+	}
+
+	var offset time.Duration
+	if !sys.lastUpdateTime.IsZero() && !other.lastUpdateTime.IsZero() {
+		offset = other.lastUpdateTime.Sub(sys.lastUpdateTime)
+	}
+
+	kept := sys.particles[:0]
+
+	for _, p := range sys.particles {
+		if !p.isAlive || len(other.particles) >= other.MaxParticles || !pred(p) {
+			kept = append(kept, p)
+			continue
+		}
+
+		other.lastID++
+
+		p.system = other
+		p.id = other.lastID
+		p.shiftTimes(offset)
+
+		other.particles = append(other.particles, p)
+	}
+
+	for idx := len(kept); idx < len(sys.particles); idx++ {
+		sys.particles[idx] = nil
+	}
+
+	sys.particles = kept
+}
+
+// shiftTimes moves p's times by d, keeping its age.
+func (p *Particle) shiftTimes(d time.Duration) {
+	if d == 0 {
+		return
+	}
+
+	p.birthTime = p.birthTime.Add(d)
+	p.deathTime = p.deathTime.Add(d)
+	p.lastUpdateTime = p.lastUpdateTime.Add(d)
+}
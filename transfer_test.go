@@ -0,0 +1,128 @@
+package twodeeparticles
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestParticleSystem_TransferTo(t *testing.T) {
+	is := is.New(t)
+
+	positions := []Vector{{0, 0}, {10, 0}, {20, 0}}
+
+	sys := NewSystem()
+	sys.MaxParticles = len(positions)
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 10 * time.Second
+	}
+
+	idx := 0
+	sys.EmissionPositionOverTime = func(d time.Duration, delta time.Duration) Vector {
+		pos := positions[idx]
+		idx++
+
+		return pos
+	}
+
+	sys.InitialVelocityOverTime = func(d time.Duration, delta time.Duration, spawnPos Vector) Vector {
+		return Vector{1, 0}
+	}
+
+	deaths := 0
+	sys.DeathFunc = func(p *Particle) {
+		deaths++
+	}
+
+	other := NewSystem()
+	other.MaxParticles = 1
+	other.VelocityOverLifetime = func(p *Particle, t NormalizedDuration, delta time.Duration) Vector {
+		return Vector{0, -1}
+	}
+
+	sys.Spawn(len(positions))
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(time.Second)
+	sys.Update(now)
+	other.Update(now)
+
+	// transfer particles with x >= 10, but other only has room for one
+	sys.TransferTo(other, func(p *Particle) bool {
+		return p.Position().X >= 10
+	})
+
+	is.Equal(sys.NumParticles(), 2)
+	is.Equal(other.NumParticles(), 1)
+	is.Equal(deaths, 0)
+
+	var p *Particle
+
+	other.ForEachParticle(func(part *Particle, t NormalizedDuration, delta time.Duration) {
+		p = part
+	}, now)
+
+	is.Equal(p.System(), other)
+	is.Equal(p.ID(), uint64(1))
+	is.Equal(p.Age(), time.Second)
+	is.True(approxEqualVector(p.Position(), Vector{11, 0}))
+
+	now = now.Add(time.Second)
+	sys.Update(now)
+	other.Update(now)
+
+	// the transferred particle keeps its lifetime, and moves according to other's callbacks
+	is.Equal(p.Age(), 2*time.Second)
+	is.Equal(p.Lifetime(), 10*time.Second)
+	is.True(approxEqualVector(p.Position(), Vector{11, -1}))
+}
+
+func TestParticleSystem_TransferTo_Clocks(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+	sys.MaxParticles = 1
+	sys.LifetimeOverTime = func(d time.Duration, delta time.Duration) time.Duration {
+		return 3 * time.Second
+	}
+
+	other := NewSystem()
+	other.MaxParticles = 1
+	other.SetTimeScale(0.5)
+
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+	other.Update(now)
+
+	now = now.Add(time.Second)
+	sys.Update(now)
+	other.Update(now)
+
+	sys.TransferTo(other, func(p *Particle) bool {
+		return true
+	})
+
+	is.Equal(other.NumParticles(), 1)
+
+	// other's clock runs at half speed, so the particle ages by one second in two seconds
+	now = now.Add(2 * time.Second)
+	other.Update(now)
+
+	var age time.Duration
+
+	other.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		age = p.Age()
+	}, now)
+
+	is.Equal(age, 2*time.Second)
+
+	now = now.Add(2 * time.Second)
+	other.Update(now)
+
+	is.Equal(other.NumParticles(), 0)
+}
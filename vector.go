@@ -21,14 +21,21 @@ type Vector struct {
 	Y float64
 }
 
+// VectorFromAngle returns a unit vector pointing in the direction of angle a, in radians.
+// An angle of 0 points along the positive X axis.
+func VectorFromAngle(a float64) Vector {
+	sin, cos := math.Sincos(a)
+	return Vector{cos, sin}
+}
+
 // Magnitude returns the length of v.
 func (v Vector) Magnitude() float64 {
-	return math.Sqrt(v.X*v.X + v.Y*v.Y)
+	return math.Hypot(v.X, v.Y)
 }
 
 // Normalize returns a vector that has the same direction as v, but whose length is one.
 // In other words, it returns a unit vector with the same direction as v.
-// If v has a length of zero, it will panic.
+// If v has a length of zero, or if its length is not finite, it will panic.
 func (v Vector) Normalize() Vector {
 	n, ok := v.TryNormalize()
 	if !ok {
@@ -40,10 +47,11 @@ func (v Vector) Normalize() Vector {
 
 // TryNormalize returns a vector that has the same direction as v, but whose length is one.
 // In other words, it returns a unit vector with the same direction as v.
-// If v has a length of zero, it will return v and false, else the described result and true.
+// If v has a length of zero, or if its length is not finite, it will return v and false, else the described result
+// and true.
 func (v Vector) TryNormalize() (Vector, bool) {
 	mag := v.Magnitude()
-	if mag == 0 {
+	if mag == 0 || math.IsInf(mag, 0) || math.IsNaN(mag) {
 		return v, false
 	}
 
@@ -59,3 +67,10 @@ func (v Vector) Add(v2 Vector) Vector {
 func (v Vector) Multiply(d float64) Vector {
 	return Vector{v.X * d, v.Y * d}
 }
+
+// Rotate returns a vector that is v rotated by angle a, in radians.
+func (v Vector) Rotate(a float64) Vector {
+	// https://matthew-brett.github.io/teaching/rotation_2d.html
+	sin, cos := math.Sincos(a)
+	return Vector{v.X*cos - v.Y*sin, v.X*sin + v.Y*cos}
+}
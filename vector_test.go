@@ -7,6 +7,17 @@ import (
 	"github.com/matryer/is"
 )
 
+func TestVectorFromAngle(t *testing.T) {
+	is := is.New(t)
+
+	v := VectorFromAngle(0)
+	is.Equal(v, Vector{1, 0})
+
+	v = VectorFromAngle(math.Pi)
+	is.True(approxEqual(v.X, -1.0))
+	is.True(approxEqual(v.Y, 0.0))
+}
+
 func TestVector_Magnitude(t *testing.T) {
 	is := is.New(t)
 	is.Equal(Vector{17, 23}.Magnitude(), math.Sqrt(17*17+23*23))
@@ -41,3 +52,46 @@ func TestVector_Multiply(t *testing.T) {
 	is := is.New(t)
 	is.Equal(Vector{17, 23}.Multiply(3), Vector{17 * 3, 23 * 3})
 }
+
+func TestVector_Rotate(t *testing.T) {
+	is := is.New(t)
+	is.True(approxEqualVector(Vector{17, 23}.Rotate(math.Pi/2.0), Vector{-23, 17}))
+}
+
+func FuzzVector_TryNormalize(f *testing.F) {
+	f.Add(17.0, 23.0)
+	f.Add(0.0, 0.0)
+	f.Add(1e300, -1e300)
+	f.Add(math.Inf(1), 0.0)
+	f.Add(math.NaN(), 1.0)
+
+	f.Fuzz(func(t *testing.T, x float64, y float64) {
+		is := is.New(t)
+
+		n, ok := Vector{x, y}.TryNormalize()
+		if !ok {
+			is.True(n == Vector{x, y} || math.IsNaN(x) || math.IsNaN(y))
+			return
+		}
+
+		is.True(math.Abs(n.Magnitude()-1.0) < 1e-9)
+	})
+}
+
+func FuzzVector_Rotate(f *testing.F) {
+	f.Add(17.0, 23.0, 1.0)
+	f.Add(0.0, 0.0, math.Pi)
+	f.Add(1e150, 1e150, -2.0)
+
+	f.Fuzz(func(t *testing.T, x float64, y float64, a float64) {
+		is := is.New(t)
+
+		v := Vector{x, y}
+		if math.IsNaN(v.Magnitude()) || math.IsInf(v.Magnitude(), 0) || math.IsNaN(a) || math.IsInf(a, 0) || math.Abs(a) > 1e6 {
+			return
+		}
+
+		r := v.Rotate(a)
+		is.True(math.Abs(r.Magnitude()-v.Magnitude()) <= 1e-9*math.Max(1.0, v.Magnitude()))
+	})
+}
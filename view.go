@@ -0,0 +1,54 @@
+package twodeeparticles
+
+// A viewTransform transforms positions relative to a system's origin into screen positions.
+type viewTransform struct {
+	origin   Vector
+	scale    float64
+	rotation float64
+	set      bool
+}
+
+// SetViewTransform sets how the system is drawn on screen: Positions relative to the system's origin are scaled by
+// scale, rotated by rotation (in radians) around the system's origin, and then moved to origin on screen. The view
+// transform is not used by the simulation itself, but it allows interactive features to convert between screen and
+// system coordinates (see ScreenToLocal and LocalToScreen), for example to spawn particles at the mouse cursor.
+// Renderers should draw particles using the same transform.
+//
+// The default view transform draws the system's origin at (0.0,0.0) on screen, with a scale of 1.0 and a rotation
+// of 0.0.
+func (sys *ParticleSystem) SetViewTransform(origin Vector, scale float64, rotation float64) {
+	sys.view = viewTransform{
+		origin:   origin,
+		scale:    scale,
+		rotation: rotation,
+		set:      true,
+	}
+}
+
+// ViewTransform returns the system's view transform (see SetViewTransform.)
+func (sys *ParticleSystem) ViewTransform() (Vector, float64, float64) {
+	if !sys.view.set {
+		return ZeroVector, 1.0, 0.0
+	}
+
+	return sys.view.origin, sys.view.scale, sys.view.rotation
+}
+
+// LocalToScreen converts v, relative to the system's origin, into a screen position according to the system's view
+// transform (see SetViewTransform.)
+func (sys *ParticleSystem) LocalToScreen(v Vector) Vector {
+	origin, scale, rotation := sys.ViewTransform()
+	return v.Multiply(scale).Rotate(rotation).Add(origin)
+}
+
+// ScreenToLocal converts the screen position v into a position relative to the system's origin, according to
+// the system's view transform (see SetViewTransform.) If the view transform's scale is 0.0, ScreenToLocal
+// returns ZeroVector.
+func (sys *ParticleSystem) ScreenToLocal(v Vector) Vector {
+	origin, scale, rotation := sys.ViewTransform()
+	if scale == 0.0 {
+		return ZeroVector
+	}
+
+	return v.Add(origin.Multiply(-1.0)).Rotate(-rotation).Multiply(1.0 / scale)
+}
@@ -0,0 +1,33 @@
+package twodeeparticles
+
+import (
+	"math"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestParticleSystem_LocalToScreen(t *testing.T) {
+	is := is.New(t)
+
+	sys := NewSystem()
+
+	is.Equal(sys.LocalToScreen(Vector{1, 2}), Vector{1, 2})
+	is.Equal(sys.ScreenToLocal(Vector{1, 2}), Vector{1, 2})
+
+	sys.SetViewTransform(Vector{100, 50}, 2.0, math.Pi/2.0)
+
+	origin, scale, rotation := sys.ViewTransform()
+	is.Equal(origin, Vector{100, 50})
+	is.Equal(scale, 2.0)
+	is.Equal(rotation, math.Pi/2.0)
+
+	is.True(approxEqualVector(sys.LocalToScreen(Vector{1, 0}), Vector{100, 52}))
+	is.True(approxEqualVector(sys.ScreenToLocal(Vector{100, 52}), Vector{1, 0}))
+
+	v := Vector{-3, 7}
+	is.True(approxEqualVector(sys.ScreenToLocal(sys.LocalToScreen(v)), v))
+
+	sys.SetViewTransform(ZeroVector, 0.0, 0.0)
+	is.Equal(sys.ScreenToLocal(v), ZeroVector)
+}
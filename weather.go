@@ -0,0 +1,221 @@
+package twodeeparticles
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// A Weather is a preset that simulates weather effects such as rain or snow. Particles are emitted across the top
+// edge of a view rectangle and fall down. Particles leaving the view at the bottom are recycled to the top edge,
+// particles leaving the view at the sides are wrapped around to the opposite side.
+//
+// The Data of a weather particle is a *WeatherDrop.
+type Weather struct {
+	// View is the rectangle that particles are simulated in, relative to the system's origin (for example,
+	// the visible part of the screen.)
+	View Rect
+
+	// Rate is the emission rate at full intensity, in particles/second.
+	Rate float64
+
+	// Intensity scales the amount of particles, in the range [0.0,1.0]. Particles leaving the view at the bottom
+	// are recycled with a probability of Intensity, or die otherwise.
+	Intensity float64
+
+	// Velocity is the falling velocity of particles in a layer with a speed of 1.0, in arbitrary units per second.
+	Velocity Vector
+
+	// Wind is added to Velocity.
+	Wind Vector
+
+	// Sway is the amplitude of particles drifting sideways, in arbitrary units per second.
+	Sway float64
+
+	// SwayFrequency is the frequency of particles drifting sideways, in Hertz.
+	SwayFrequency float64
+
+	// Layers are the depth layers that particles are spawned into.
+	Layers []DepthLayer
+
+	sys  *ParticleSystem
+	rand *rand.Rand
+}
+
+// A DepthLayer is a layer of particles in an effect that simulates depth. Particles in layers farther away usually
+// move slower, and appear smaller and more transparent than particles in closer layers.
+type DepthLayer struct {
+	// Weight is the relative probability of a particle being spawned into this layer.
+	Weight float64
+
+	// Speed is multiplied with a particle's velocity.
+	Speed float64
+
+	// Scale is a particle's scale.
+	Scale float64
+
+	// Alpha is a particle's opacity, in the range [0.0,1.0].
+	Alpha float64
+}
+
+// A WeatherDrop is the data attached to a weather particle.
+type WeatherDrop struct {
+	// Layer is the index of the particle's depth layer in Weather.Layers.
+	Layer int
+
+	phase float64
+}
+
+// NewWeather returns a new weather effect for view, with full intensity. The returned effect is not configured
+// yet and must be configured using its fields. For preconfigured effects, see NewRain and NewSnow.
+func NewWeather(view Rect, maxParticles int, rand *rand.Rand) *Weather {
+	w := Weather{
+		View:      view,
+		Intensity: 1.0,
+		sys:       NewSystem(),
+		rand:      rand,
+	}
+
+	w.sys.MaxParticles = maxParticles
+	w.sys.EmissionRateOverTime = w.emissionRate
+	w.sys.EmissionPositionOverTime = w.emissionPosition
+	w.sys.LifetimeOverTime = w.lifetime
+	w.sys.DataOverLifetime = w.data
+	w.sys.UpdateFunc = w.update
+	w.sys.VelocityOverLifetime = w.velocity
+	w.sys.ScaleOverLifetime = w.scale
+	w.sys.ColorOverLifetime = w.color
+
+	return &w
+}
+
+// NewRain returns a new rain effect for view.
+func NewRain(view Rect, rand *rand.Rand) *Weather {
+	w := NewWeather(view, 1000, rand)
+
+	w.Rate = 400.0
+	w.Velocity = Vector{0.0, 600.0}
+
+	w.Layers = []DepthLayer{
+		{Weight: 1.0, Speed: 0.6, Scale: 0.1, Alpha: 0.4},
+		{Weight: 1.0, Speed: 0.8, Scale: 0.15, Alpha: 0.6},
+		{Weight: 1.0, Speed: 1.0, Scale: 0.2, Alpha: 0.8},
+	}
+
+	return w
+}
+
+// NewSnow returns a new snow effect for view.
+func NewSnow(view Rect, rand *rand.Rand) *Weather {
+	w := NewWeather(view, 500, rand)
+
+	w.Rate = 60.0
+	w.Velocity = Vector{0.0, 60.0}
+	w.Sway = 20.0
+	w.SwayFrequency = 0.5
+
+	w.Layers = []DepthLayer{
+		{Weight: 2.0, Speed: 0.5, Scale: 0.1, Alpha: 0.5},
+		{Weight: 1.5, Speed: 0.75, Scale: 0.2, Alpha: 0.75},
+		{Weight: 1.0, Speed: 1.0, Scale: 0.3, Alpha: 1.0},
+	}
+
+	return w
+}
+
+// System returns the particle system that simulates w.
+func (w *Weather) System() *ParticleSystem {
+	return w.sys
+}
+
+func (w *Weather) emissionRate(d time.Duration, delta time.Duration) float64 {
+	return w.Rate * math.Max(0.0, math.Min(w.Intensity, 1.0))
+}
+
+func (w *Weather) emissionPosition(d time.Duration, delta time.Duration) Vector {
+	return w.topEdgePosition()
+}
+
+func (w *Weather) topEdgePosition() Vector {
+	return Vector{w.View.Min.X + w.rand.Float64()*w.View.Width(), w.View.Min.Y}
+}
+
+func (w *Weather) lifetime(d time.Duration, delta time.Duration) time.Duration {
+	return InfiniteLifetime
+}
+
+func (w *Weather) data(old any, t NormalizedDuration, delta time.Duration) any {
+	if old != nil {
+		return old
+	}
+
+	return &WeatherDrop{
+		Layer: chooseLayer(w.Layers, w.rand),
+		phase: w.rand.Float64() * 2.0 * math.Pi,
+	}
+}
+
+func (w *Weather) update(p *Particle, t NormalizedDuration, delta time.Duration) {
+	width := w.View.Width()
+
+	switch {
+	case p.position.X < w.View.Min.X:
+		p.position.X += width
+	case p.position.X >= w.View.Max.X:
+		p.position.X -= width
+	}
+
+	if p.position.Y < w.View.Max.Y {
+		return
+	}
+
+	if w.rand.Float64() >= w.Intensity {
+		p.Kill()
+		return
+	}
+
+	p.position = w.topEdgePosition()
+}
+
+func (w *Weather) velocity(p *Particle, t NormalizedDuration, delta time.Duration) Vector {
+	drop := p.Data().(*WeatherDrop) //nolint:forcetypeassert // we know this is a *WeatherDrop
+	layer := w.layer(drop)
+
+	v := w.Velocity.Add(w.Wind).Multiply(layer.Speed)
+
+	if w.Sway != 0 {
+		s := p.Age().Seconds()
+		v.X += math.Sin(2.0*math.Pi*w.SwayFrequency*s+drop.phase) * w.Sway * layer.Speed
+	}
+
+	return v
+}
+
+func (w *Weather) scale(p *Particle, t NormalizedDuration, delta time.Duration) Vector {
+	drop := p.Data().(*WeatherDrop) //nolint:forcetypeassert // we know this is a *WeatherDrop
+	s := w.layer(drop).Scale
+
+	return Vector{s, s}
+}
+
+func (w *Weather) color(p *Particle, t NormalizedDuration, delta time.Duration) color.Color {
+	drop := p.Data().(*WeatherDrop) //nolint:forcetypeassert // we know this is a *WeatherDrop
+	a := w.layer(drop).Alpha
+
+	return color.NRGBA{255, 255, 255, uint8(a * 255.0)}
+}
+
+func (w *Weather) layer(drop *WeatherDrop) DepthLayer {
+	if drop.Layer < 0 || drop.Layer >= len(w.Layers) {
+		return DepthLayer{Weight: 1.0, Speed: 1.0, Scale: 1.0, Alpha: 1.0}
+	}
+
+	return w.Layers[drop.Layer]
+}
+
+func chooseLayer(layers []DepthLayer, rand *rand.Rand) int {
+	return sampleWeighted(len(layers), func(idx int) float64 {
+		return layers[idx].Weight
+	}, rand)
+}
@@ -0,0 +1,93 @@
+package twodeeparticles
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestWeather_Emission(t *testing.T) {
+	is := is.New(t)
+
+	view := Rect{Vector{-100, -50}, Vector{100, 50}}
+	w := NewRain(view, rand.New(rand.NewSource(1))) //nolint:gosec // no crypto
+	sys := w.System()
+
+	now := time.Now()
+	sys.Update(now)
+
+	now = now.Add(100 * time.Millisecond)
+	sys.Update(now)
+
+	is.True(sys.NumParticles() > 0)
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		drop := p.Data().(*WeatherDrop) //nolint:forcetypeassert // we know this is a *WeatherDrop
+
+		is.True(drop.Layer >= 0 && drop.Layer < len(w.Layers))
+		is.True(p.Position().X >= view.Min.X && p.Position().X < view.Max.X)
+		is.True(p.Position().Y >= view.Min.Y && p.Position().Y < view.Max.Y)
+		is.Equal(p.Scale(), Vector{w.Layers[drop.Layer].Scale, w.Layers[drop.Layer].Scale})
+	}, now)
+}
+
+func TestWeather_Recycle(t *testing.T) {
+	is := is.New(t)
+
+	view := Rect{Vector{-100, -50}, Vector{100, 50}}
+	w := NewWeather(view, 1, rand.New(rand.NewSource(1))) //nolint:gosec // no crypto
+	w.Velocity = Vector{0, 80}
+	w.Layers = []DepthLayer{{Weight: 1.0, Speed: 1.0, Scale: 1.0, Alpha: 1.0}}
+
+	sys := w.System()
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	for i := 0; i < 3; i++ {
+		now = now.Add(1 * time.Second)
+		sys.Update(now)
+	}
+
+	is.Equal(sys.NumParticles(), 1)
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.True(view.Contains(p.Position()))
+	}, now)
+
+	w.Intensity = 0.0
+
+	for i := 0; i < 3; i++ {
+		now = now.Add(1 * time.Second)
+		sys.Update(now)
+	}
+
+	is.Equal(sys.NumParticles(), 0)
+}
+
+func TestWeather_Wrap(t *testing.T) {
+	is := is.New(t)
+
+	view := Rect{Vector{-100, -50}, Vector{100, 50}}
+	w := NewWeather(view, 1, rand.New(rand.NewSource(1))) //nolint:gosec // no crypto
+	w.Wind = Vector{150, 0}
+	w.Layers = []DepthLayer{{Weight: 1.0, Speed: 1.0, Scale: 1.0, Alpha: 1.0}}
+
+	sys := w.System()
+	sys.Spawn(1)
+
+	now := time.Now()
+	sys.Update(now)
+
+	for i := 0; i < 5; i++ {
+		now = now.Add(1 * time.Second)
+		sys.Update(now)
+	}
+
+	sys.ForEachParticle(func(p *Particle, t NormalizedDuration, delta time.Duration) {
+		is.True(p.Position().X >= view.Min.X-150 && p.Position().X < view.Max.X+150)
+	}, now)
+}